@@ -0,0 +1,92 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func dataSourceNsxtDefaultFirewallSection() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtDefaultFirewallSectionRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "Unique ID of this resource",
+				Computed:    true,
+			},
+			"section_type": {
+				Type:         schema.TypeString,
+				Description:  "Type of the rules which the default section contains",
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(firewallSectionTypeValues, false),
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Description: "The display name of this resource",
+				Computed:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of this resource",
+				Computed:    true,
+			},
+			"stateful": {
+				Type:        schema.TypeBool,
+				Description: "Stateful or Stateless nature of firewall section",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNsxtDefaultFirewallSectionRead(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return dataSourceNotSupportedError()
+	}
+
+	sectionType := d.Get("section_type").(string)
+	var obj manager.FirewallSection
+	found := false
+	lister := func(info *paginationInfo) error {
+		info.LocalVarOptionals["type_"] = sectionType
+		objList, _, err := nsxClient.ServicesApi.ListSections(nsxClient.Context, info.LocalVarOptionals)
+		if err != nil {
+			return fmt.Errorf("Error while reading Firewall sections: %v", err)
+		}
+
+		info.PageCount = int64(len(objList.Results))
+		info.TotalCount = objList.ResultCount
+		info.Cursor = objList.Cursor
+
+		// go over the list to find the default one
+		for _, objInList := range objList.Results {
+			if objInList.IsDefault && objInList.SectionType == sectionType {
+				obj = objInList
+				found = true
+			}
+		}
+		return nil
+	}
+	total, err := handlePagination(lister)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("Default %s firewall section was not found among %d sections", sectionType, total)
+	}
+
+	d.SetId(obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	d.Set("stateful", obj.Stateful)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceNsxtDefaultFirewallSection_basic(t *testing.T) {
+	testResourceName := "data.nsxt_default_firewall_section.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXDefaultFirewallSectionReadTemplate("LAYER3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testResourceName, "id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "display_name"),
+					resource.TestCheckResourceAttr(testResourceName, "section_type", "LAYER3"),
+				),
+			},
+			{
+				Config: testAccNSXDefaultFirewallSectionReadTemplate("LAYER2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testResourceName, "id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "display_name"),
+					resource.TestCheckResourceAttr(testResourceName, "section_type", "LAYER2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNSXDefaultFirewallSectionReadTemplate(sectionType string) string {
+	return fmt.Sprintf(`
+data "nsxt_default_firewall_section" "test" {
+  section_type = "%s"
+}`, sectionType)
+}
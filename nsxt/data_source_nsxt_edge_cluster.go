@@ -48,6 +48,11 @@ func dataSourceNsxtEdgeCluster() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
+			"member_count": {
+				Type:        schema.TypeInt,
+				Description: "Number of edge nodes in this cluster",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -78,21 +83,33 @@ func dataSourceNsxtEdgeClusterRead(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("Error obtaining edge cluster ID or name during read")
 	} else {
 		// Get by full name/prefix
-		// TODO use 2nd parameter localVarOptionals for paging
-		objList, _, err := nsxClient.NetworkTransportApi.ListEdgeClusters(nsxClient.Context, nil)
-		if err != nil {
-			return fmt.Errorf("Error while reading edge clusters: %v", err)
-		}
 		// go over the list to find the correct one (prefer a perfect match. If not - prefix match)
 		var perfectMatch []manager.EdgeCluster
 		var prefixMatch []manager.EdgeCluster
-		for _, objInList := range objList.Results {
-			if strings.HasPrefix(objInList.DisplayName, objName) {
-				prefixMatch = append(prefixMatch, objInList)
+		lister := func(info *paginationInfo) error {
+			objList, _, err := nsxClient.NetworkTransportApi.ListEdgeClusters(nsxClient.Context, info.LocalVarOptionals)
+			if err != nil {
+				return fmt.Errorf("Error while reading edge clusters: %v", err)
 			}
-			if objInList.DisplayName == objName {
-				perfectMatch = append(perfectMatch, objInList)
+
+			info.PageCount = int64(len(objList.Results))
+			info.TotalCount = objList.ResultCount
+			info.Cursor = objList.Cursor
+
+			for _, objInList := range objList.Results {
+				if strings.HasPrefix(objInList.DisplayName, objName) {
+					prefixMatch = append(prefixMatch, objInList)
+				}
+				if objInList.DisplayName == objName {
+					perfectMatch = append(perfectMatch, objInList)
+				}
 			}
+			return nil
+		}
+
+		_, err := handlePagination(lister)
+		if err != nil {
+			return err
 		}
 		if len(perfectMatch) > 0 {
 			if len(perfectMatch) > 1 {
@@ -114,6 +131,7 @@ func dataSourceNsxtEdgeClusterRead(d *schema.ResourceData, m interface{}) error
 	d.Set("description", obj.Description)
 	d.Set("deployment_type", obj.DeploymentType)
 	d.Set("member_node_type", obj.MemberNodeType)
+	d.Set("member_count", len(obj.Members))
 
 	return nil
 }
@@ -25,6 +25,7 @@ func TestAccDataSourceNsxtEdgeCluster_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(testResourceName, "id"),
 					resource.TestCheckResourceAttrSet(testResourceName, "member_node_type"),
 					resource.TestCheckResourceAttrSet(testResourceName, "deployment_type"),
+					resource.TestCheckResourceAttrSet(testResourceName, "member_count"),
 				),
 			},
 		},
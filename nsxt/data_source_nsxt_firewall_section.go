@@ -35,6 +35,21 @@ func dataSourceNsxtFirewallSection() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
+			"section_type": {
+				Type:        schema.TypeString,
+				Description: "Type of the rules which a section can contain",
+				Computed:    true,
+			},
+			"stateful": {
+				Type:        schema.TypeBool,
+				Description: "Stateful or Stateless nature of firewall section",
+				Computed:    true,
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Description: "A boolean flag which reflects whether a firewall section is default section or not",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -98,6 +113,9 @@ func dataSourceNsxtFirewallSectionRead(d *schema.ResourceData, m interface{}) er
 	d.SetId(obj.Id)
 	d.Set("display_name", obj.DisplayName)
 	d.Set("description", obj.Description)
+	d.Set("section_type", obj.SectionType)
+	d.Set("stateful", obj.Stateful)
+	d.Set("is_default", obj.IsDefault)
 
 	return nil
 }
@@ -34,6 +34,8 @@ func TestAccDataSourceNsxtFirewallSection_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(testResourceName, "display_name", name),
 					resource.TestCheckResourceAttr(testResourceName, "description", name),
+					resource.TestCheckResourceAttr(testResourceName, "section_type", "LAYER3"),
+					resource.TestCheckResourceAttr(testResourceName, "is_default", "false"),
 				),
 			},
 		},
@@ -0,0 +1,125 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func dataSourceNsxtIPProtocolNsService() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtIPProtocolNsServiceRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "Unique ID of this resource",
+				Optional:    true,
+				Computed:    true,
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Description: "The display name of this resource",
+				Optional:    true,
+				Computed:    true,
+			},
+			"protocol": {
+				Type:         schema.TypeInt,
+				Description:  "IP protocol number",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 255),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of this resource",
+				Computed:    true,
+			},
+			"default_service": {
+				Type:        schema.TypeBool,
+				Description: "A boolean flag which reflects whether this is a default NSServices which can't be modified/deleted",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNsxtIPProtocolNsServiceRead(d *schema.ResourceData, m interface{}) error {
+	// Read IP protocol NS Service by id, name or protocol number
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return dataSourceNotSupportedError()
+	}
+
+	objID := d.Get("id").(string)
+	objName := d.Get("display_name").(string)
+	// GetOk can not be used here since protocol number 0 is valid
+	protocol, protocolSet := d.GetOkExists("protocol")
+	var obj manager.IpProtocolNsService
+	if objID != "" {
+		// Get by id
+		objGet, resp, err := nsxClient.GroupingObjectsApi.ReadIpProtocolNSService(nsxClient.Context, objID)
+
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("IP protocol NS service %s was not found", objID)
+		}
+		if err != nil {
+			return fmt.Errorf("Error while reading IP protocol NS service %s: %v", objID, err)
+		}
+		if objGet.NsserviceElement.ResourceType != "IPProtocolNSService" {
+			return fmt.Errorf("NS service %s is not an IP protocol service", objID)
+		}
+		obj = objGet
+	} else if objName != "" || protocolSet {
+		// Get by full name and/or protocol number
+		candidates, total, err := listNsServiceIDs(nsxClient, objName)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, candidateID := range candidates {
+			objGet, resp, err := nsxClient.GroupingObjectsApi.ReadIpProtocolNSService(nsxClient.Context, candidateID)
+			if err != nil {
+				if !isNsServiceTypeMismatchResponse(resp) {
+					return handleManagerAPIError(fmt.Sprintf("Error while reading NS service %s", candidateID), err)
+				}
+				// Services of other types can not be read as IP protocol services
+				log.Printf("[DEBUG] Skipping NS service %s: %v", candidateID, err)
+				continue
+			}
+			if objGet.NsserviceElement.ResourceType != "IPProtocolNSService" {
+				continue
+			}
+			if protocolSet && objGet.NsserviceElement.ProtocolNumber != int64(protocol.(int)) {
+				continue
+			}
+			if found {
+				return fmt.Errorf("Found multiple IP protocol NS services matching name '%s' and protocol %v", objName, protocol)
+			}
+			obj = objGet
+			found = true
+		}
+
+		if !found {
+			return fmt.Errorf("IP protocol NS service matching name '%s' and protocol %v was not found among %d services", objName, protocol, total)
+		}
+	} else {
+		return fmt.Errorf("Error obtaining IP protocol NS service ID, name or protocol during read")
+	}
+
+	d.SetId(obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	d.Set("default_service", obj.DefaultService)
+	d.Set("protocol", obj.NsserviceElement.ProtocolNumber)
+
+	return nil
+}
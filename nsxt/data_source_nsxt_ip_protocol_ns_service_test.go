@@ -0,0 +1,45 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceNsxtIPProtocolNsService_basic(t *testing.T) {
+	serviceName := getAccTestDataSourceName()
+	testResourceName := "data.nsxt_ip_protocol_ns_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXIPProtocolNsServiceReadTemplate(serviceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", serviceName),
+					resource.TestCheckResourceAttr(testResourceName, "protocol", "47"),
+					resource.TestCheckResourceAttr(testResourceName, "default_service", "false"),
+					resource.TestCheckResourceAttrPair(testResourceName, "id", "nsxt_ip_protocol_ns_service.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNSXIPProtocolNsServiceReadTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "nsxt_ip_protocol_ns_service" "test" {
+  display_name = "%s"
+  protocol     = 47
+}
+
+data "nsxt_ip_protocol_ns_service" "test" {
+  display_name = "${nsxt_ip_protocol_ns_service.test.display_name}"
+  protocol     = 47
+}`, name)
+}
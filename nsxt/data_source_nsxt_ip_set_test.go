@@ -0,0 +1,97 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceNsxtIPSet_basic(t *testing.T) {
+	name := getAccTestDataSourceName()
+	testResourceName := "data.nsxt_ip_set.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXIpSetCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXIPSetReadTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", name),
+					resource.TestCheckResourceAttr(testResourceName, "description", "Acceptance Test"),
+					resource.TestCheckResourceAttr(testResourceName, "ip_addresses.#", "1"),
+					resource.TestCheckResourceAttrPair(testResourceName, "id", "nsxt_ip_set.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceNsxtIPSet_byTag(t *testing.T) {
+	name := getAccTestDataSourceName()
+	testResourceName := "data.nsxt_ip_set.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXIpSetCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXIPSetReadByTagTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", name),
+					resource.TestCheckResourceAttr(testResourceName, "ip_addresses.#", "1"),
+					resource.TestCheckResourceAttrPair(testResourceName, "id", "nsxt_ip_set.test2", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNSXIPSetReadTemplate(name string) string {
+	return testAccNSXIpSetCreateTemplate(name) + `
+data "nsxt_ip_set" "test" {
+  display_name = nsxt_ip_set.test.display_name
+}`
+}
+
+func testAccNSXIPSetReadByTagTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "nsxt_ip_set" "test1" {
+  display_name = "%s"
+  ip_addresses = ["1.1.1.1"]
+
+  tag {
+    scope = "team"
+    tag   = "blue"
+  }
+}
+
+resource "nsxt_ip_set" "test2" {
+  display_name = "%s"
+  ip_addresses = ["2.2.2.2"]
+
+  tag {
+    scope = "team"
+    tag   = "red"
+  }
+}
+
+data "nsxt_ip_set" "test" {
+  display_name = "%s"
+  tag_scope    = "team"
+  tag_value    = "red"
+
+  depends_on = [nsxt_ip_set.test1, nsxt_ip_set.test2]
+}`, name, name, name)
+}
@@ -0,0 +1,142 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func dataSourceNsxtL4PortSetNsService() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtL4PortSetNsServiceRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "Unique ID of this resource",
+				Optional:    true,
+				Computed:    true,
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Description: "The display name of this resource",
+				Optional:    true,
+				Computed:    true,
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Description:  "L4 protocol",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(protocolValues, false),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of this resource",
+				Computed:    true,
+			},
+			"default_service": {
+				Type:        schema.TypeBool,
+				Description: "A boolean flag which reflects whether this is a default NSServices which can't be modified/deleted",
+				Computed:    true,
+			},
+			"destination_ports": {
+				Type:        schema.TypeSet,
+				Description: "Set of destination ports",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"source_ports": {
+				Type:        schema.TypeSet,
+				Description: "Set of source ports",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNsxtL4PortSetNsServiceRead(d *schema.ResourceData, m interface{}) error {
+	// Read L4 port set NS Service by name or id, optionally filtered by protocol
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return dataSourceNotSupportedError()
+	}
+
+	objID := d.Get("id").(string)
+	objName := d.Get("display_name").(string)
+	protocol := d.Get("protocol").(string)
+	var obj manager.L4PortSetNsService
+	if objID != "" {
+		// Get by id
+		objGet, resp, err := nsxClient.GroupingObjectsApi.ReadL4PortSetNSService(nsxClient.Context, objID)
+
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("L4 port set NS service %s was not found", objID)
+		}
+		if err != nil {
+			return fmt.Errorf("Error while reading L4 port set NS service %s: %v", objID, err)
+		}
+		if objGet.NsserviceElement.ResourceType != "L4PortSetNSService" {
+			return fmt.Errorf("NS service %s is not an L4 port set service", objID)
+		}
+		obj = objGet
+	} else if objName != "" {
+		// Get by full name
+		candidates, total, err := listNsServiceIDs(nsxClient, objName)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, candidateID := range candidates {
+			objGet, resp, err := nsxClient.GroupingObjectsApi.ReadL4PortSetNSService(nsxClient.Context, candidateID)
+			if err != nil {
+				if !isNsServiceTypeMismatchResponse(resp) {
+					return handleManagerAPIError(fmt.Sprintf("Error while reading NS service %s", candidateID), err)
+				}
+				// Services of other types can not be read as L4 port set services
+				log.Printf("[DEBUG] Skipping NS service %s: %v", candidateID, err)
+				continue
+			}
+			if objGet.NsserviceElement.ResourceType != "L4PortSetNSService" {
+				continue
+			}
+			if protocol != "" && objGet.NsserviceElement.L4Protocol != protocol {
+				continue
+			}
+			if found {
+				return fmt.Errorf("Found multiple L4 port set NS services with name '%s'", objName)
+			}
+			obj = objGet
+			found = true
+		}
+
+		if !found {
+			return fmt.Errorf("L4 port set NS service with name '%s' was not found among %d services", objName, total)
+		}
+	} else {
+		return fmt.Errorf("Error obtaining L4 port set NS service ID or name during read")
+	}
+
+	if protocol != "" && obj.NsserviceElement.L4Protocol != protocol {
+		return fmt.Errorf("L4 port set NS service %s has protocol %s, expected %s", obj.Id, obj.NsserviceElement.L4Protocol, protocol)
+	}
+
+	d.SetId(obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	d.Set("default_service", obj.DefaultService)
+	d.Set("protocol", obj.NsserviceElement.L4Protocol)
+	d.Set("destination_ports", obj.NsserviceElement.DestinationPorts)
+	d.Set("source_ports", obj.NsserviceElement.SourcePorts)
+
+	return nil
+}
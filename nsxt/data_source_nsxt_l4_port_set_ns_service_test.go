@@ -0,0 +1,75 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceNsxtL4PortSetNsService_basic(t *testing.T) {
+	serviceName := getAccTestDataSourceName()
+	testResourceName := "data.nsxt_l4_port_set_ns_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXL4PortSetNsServiceReadTemplate(serviceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", serviceName),
+					resource.TestCheckResourceAttr(testResourceName, "protocol", "TCP"),
+					resource.TestCheckResourceAttr(testResourceName, "default_service", "false"),
+					resource.TestCheckResourceAttr(testResourceName, "destination_ports.#", "2"),
+					resource.TestCheckResourceAttrPair(testResourceName, "id", "nsxt_l4_port_set_ns_service.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceNsxtL4PortSetNsService_systemOwned(t *testing.T) {
+	serviceName := "DNS-UDP"
+	testResourceName := "data.nsxt_l4_port_set_ns_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXL4PortSetNsServiceSystemReadTemplate(serviceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", serviceName),
+					resource.TestCheckResourceAttr(testResourceName, "protocol", "UDP"),
+					resource.TestCheckResourceAttr(testResourceName, "default_service", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNSXL4PortSetNsServiceReadTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "nsxt_l4_port_set_ns_service" "test" {
+  display_name      = "%s"
+  protocol          = "TCP"
+  destination_ports = ["80", "8080-8081"]
+}
+
+data "nsxt_l4_port_set_ns_service" "test" {
+  display_name = "${nsxt_l4_port_set_ns_service.test.display_name}"
+  protocol     = "TCP"
+}`, name)
+}
+
+func testAccNSXL4PortSetNsServiceSystemReadTemplate(name string) string {
+	return fmt.Sprintf(`
+data "nsxt_l4_port_set_ns_service" "test" {
+  display_name = "%s"
+  protocol     = "UDP"
+}`, name)
+}
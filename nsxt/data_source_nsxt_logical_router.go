@@ -0,0 +1,131 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+var logicalRouterTypeValues = []string{"TIER0", "TIER1"}
+
+func dataSourceNsxtLogicalRouter() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtLogicalRouterRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "Unique ID of this resource",
+				Computed:    true,
+			},
+			"display_name": {
+				Type:         schema.TypeString,
+				Description:  "The display name of this resource",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"router_type": {
+				Type:         schema.TypeString,
+				Description:  "Type of the logical router",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(logicalRouterTypeValues, false),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of this resource",
+				Computed:    true,
+			},
+			"edge_cluster_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the edge cluster connected to this router",
+				Computed:    true,
+			},
+			"high_availability_mode": {
+				Type:        schema.TypeString,
+				Description: "The High availability mode of this router",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNsxtLogicalRouterRead(d *schema.ResourceData, m interface{}) error {
+	// Read a logical router of any type by name
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return dataSourceNotSupportedError()
+	}
+
+	objName := d.Get("display_name").(string)
+	routerType := d.Get("router_type").(string)
+
+	// Get by full name/prefix
+	var perfectMatch []manager.LogicalRouter
+	var prefixMatch []manager.LogicalRouter
+	lister := func(info *paginationInfo) error {
+		if routerType != "" {
+			info.LocalVarOptionals["routerType"] = routerType
+		}
+		objList, _, err := nsxClient.LogicalRoutingAndServicesApi.ListLogicalRouters(nsxClient.Context, info.LocalVarOptionals)
+		if err != nil {
+			return fmt.Errorf("Error while reading logical routers: %v", err)
+		}
+
+		info.PageCount = int64(len(objList.Results))
+		info.TotalCount = objList.ResultCount
+		info.Cursor = objList.Cursor
+
+		// go over the list to find the correct one (prefer a perfect match. If not - prefix match)
+		for _, objInList := range objList.Results {
+			if routerType != "" && objInList.RouterType != routerType {
+				continue
+			}
+			if strings.HasPrefix(objInList.DisplayName, objName) {
+				prefixMatch = append(prefixMatch, objInList)
+			}
+			if objInList.DisplayName == objName {
+				perfectMatch = append(perfectMatch, objInList)
+			}
+		}
+		return nil
+	}
+
+	total, err := handlePagination(lister)
+	if err != nil {
+		return err
+	}
+
+	var obj manager.LogicalRouter
+	if len(perfectMatch) > 0 {
+		if len(perfectMatch) > 1 {
+			if routerType == "" {
+				return fmt.Errorf("Found multiple logical routers with name '%s', please specify router_type to narrow down the search", objName)
+			}
+			return fmt.Errorf("Found multiple logical routers with name '%s' and type %s", objName, routerType)
+		}
+		obj = perfectMatch[0]
+	} else if len(prefixMatch) > 0 {
+		if len(prefixMatch) > 1 {
+			return fmt.Errorf("Found multiple logical routers with name starting with '%s'", objName)
+		}
+		obj = prefixMatch[0]
+	} else {
+		return fmt.Errorf("Logical router with name '%s' was not found among %d objects", objName, total)
+	}
+
+	d.SetId(obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("router_type", obj.RouterType)
+	d.Set("description", obj.Description)
+	d.Set("edge_cluster_id", obj.EdgeClusterId)
+	d.Set("high_availability_mode", obj.HighAvailabilityMode)
+
+	return nil
+}
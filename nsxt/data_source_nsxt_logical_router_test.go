@@ -0,0 +1,67 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceNsxtLogicalRouter_basic(t *testing.T) {
+	routerName := getAccTestDataSourceName()
+	testResourceName := "data.nsxt_logical_router.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccDataSourceNsxtTier1RouterDeleteByName(routerName)
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccDataSourceNsxtTier1RouterCreate(routerName); err != nil {
+						panic(err)
+					}
+				},
+				Config: testAccNSXLogicalRouterReadTemplate(routerName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testResourceName, "id"),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", routerName),
+					resource.TestCheckResourceAttr(testResourceName, "description", routerName),
+					resource.TestCheckResourceAttr(testResourceName, "router_type", "TIER1"),
+				),
+			},
+			{
+				Config: testAccNSXLogicalRouterReadTemplate(routerName, "TIER1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", routerName),
+					resource.TestCheckResourceAttr(testResourceName, "router_type", "TIER1"),
+				),
+			},
+			{
+				Config:      testAccNSXLogicalRouterReadTemplate(routerName, "TIER0"),
+				ExpectError: regexp.MustCompile("was not found"),
+			},
+		},
+	})
+}
+
+func testAccNSXLogicalRouterReadTemplate(name string, routerType string) string {
+	if routerType == "" {
+		return fmt.Sprintf(`
+data "nsxt_logical_router" "test" {
+  display_name = "%s"
+}`, name)
+	}
+	return fmt.Sprintf(`
+data "nsxt_logical_router" "test" {
+  display_name = "%s"
+  router_type  = "%s"
+}`, name, routerType)
+}
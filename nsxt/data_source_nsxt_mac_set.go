@@ -0,0 +1,132 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func dataSourceNsxtMacSet() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtMacSetRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "Unique ID of this resource",
+				Optional:    true,
+				Computed:    true,
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Description: "The display name of this resource",
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of this resource",
+				Optional:    true,
+				Computed:    true,
+			},
+			"tag_scope": {
+				Type:        schema.TypeString,
+				Description: "Only consider objects that have a tag with this scope",
+				Optional:    true,
+			},
+			"tag_value": {
+				Type:        schema.TypeString,
+				Description: "Only consider objects that have a tag with this value, together with tag_scope if specified",
+				Optional:    true,
+			},
+			"mac_addresses": {
+				Type:        schema.TypeSet,
+				Description: "Set of MAC addresses in this MAC set",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNsxtMacSetRead(d *schema.ResourceData, m interface{}) error {
+	// Read MAC Set by name or id
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return dataSourceNotSupportedError()
+	}
+
+	objID := d.Get("id").(string)
+	objName := d.Get("display_name").(string)
+	tagScope := d.Get("tag_scope").(string)
+	tagValue := d.Get("tag_value").(string)
+	if objID == "" && (tagScope != "" || tagValue != "") {
+		// Objects are filtered by tag with NSX search, and then retrieved by id
+		var err error
+		objID, err = searchObjectIDByNameAndTag(m, "MACSet", "MAC set", objName, tagScope, tagValue)
+		if err != nil {
+			return err
+		}
+	}
+	var obj manager.MacSet
+	if objID != "" {
+		// Get by id
+		objGet, resp, err := nsxClient.GroupingObjectsApi.ReadMACSet(nsxClient.Context, objID)
+
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("MAC set %s was not found", objID)
+		}
+		if err != nil {
+			return fmt.Errorf("Error while reading MAC set %s: %v", objID, err)
+		}
+		obj = objGet
+	} else if objName != "" {
+		// Get by full name
+		found := false
+		lister := func(info *paginationInfo) error {
+			objList, _, err := nsxClient.GroupingObjectsApi.ListMACSets(nsxClient.Context, info.LocalVarOptionals)
+			if err != nil {
+				return fmt.Errorf("Error while reading MAC sets: %v", err)
+			}
+			info.PageCount = int64(len(objList.Results))
+			info.TotalCount = objList.ResultCount
+			info.Cursor = objList.Cursor
+
+			// go over the list to find the correct one
+			for _, objInList := range objList.Results {
+				if objInList.DisplayName == objName {
+					if found {
+						return fmt.Errorf("Found multiple MAC sets with name '%s'", objName)
+					}
+					obj = objInList
+					found = true
+				}
+			}
+			return nil
+		}
+
+		total, err := handlePagination(lister)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("MAC set with name '%s' was not found among %d MAC sets", objName, total)
+		}
+	} else {
+		return fmt.Errorf("Error obtaining MAC set ID or name during read")
+	}
+
+	d.SetId(obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	d.Set("mac_addresses", obj.MacAddresses)
+
+	return nil
+}
@@ -0,0 +1,67 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNsxtManagerInfo() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtManagerInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Description: "NSX release version in major.minor.patch format",
+				Computed:    true,
+			},
+			"product_version": {
+				Type:        schema.TypeString,
+				Description: "Full NSX product version, including build number",
+				Computed:    true,
+			},
+			"node_uuid": {
+				Type:        schema.TypeString,
+				Description: "UUID of the NSX manager node",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// getShortNSXVersion trims build information from full NSX version
+func getShortNSXVersion(productVersion string) string {
+	parts := strings.Split(productVersion, ".")
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+	return strings.Join(parts, ".")
+}
+
+func dataSourceNsxtManagerInfoRead(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return dataSourceNotSupportedError()
+	}
+
+	nodeProperties, resp, err := nsxClient.NsxComponentAdministrationApi.ReadNodeProperties(nsxClient.Context)
+	if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return fmt.Errorf("Authentication with NSX manager failed (status %d). Please check credentials of the provider", resp.StatusCode)
+	}
+	if err != nil {
+		return fmt.Errorf("NSX manager is not reachable: %v. Please check connectivity settings of the provider", err)
+	}
+
+	d.SetId(nodeProperties.NodeUuid)
+	d.Set("version", getShortNSXVersion(nodeProperties.NodeVersion))
+	d.Set("product_version", nodeProperties.NodeVersion)
+	d.Set("node_uuid", nodeProperties.NodeUuid)
+
+	return nil
+}
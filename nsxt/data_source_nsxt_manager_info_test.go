@@ -0,0 +1,45 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceNsxtManagerInfo_basic(t *testing.T) {
+	testResourceName := "data.nsxt_manager_info.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nsxt_manager_info" "test" {
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testResourceName, "node_uuid"),
+					resource.TestCheckResourceAttrSet(testResourceName, "product_version"),
+					resource.TestMatchResourceAttr(testResourceName, "version", regexp.MustCompile(`^\d+\.\d+\.\d+$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestGetShortNSXVersion(t *testing.T) {
+	cases := map[string]string{
+		"3.1.0.0.0.17107167": "3.1.0",
+		"2.5.1":              "2.5.1",
+		"3.0":                "3.0",
+	}
+	for productVersion, expected := range cases {
+		if version := getShortNSXVersion(productVersion); version != expected {
+			t.Errorf("Expected version %s for %s, got %s", expected, productVersion, version)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func dataSourceNsxtNatRule() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtNatRuleRead,
+
+		Schema: map[string]*schema.Schema{
+			"logical_router_id": {
+				Type:        schema.TypeString,
+				Description: "Logical router id",
+				Required:    true,
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Description: "The display name of this resource",
+				Optional:    true,
+				Computed:    true,
+			},
+			"match_destination_network": {
+				Type:        schema.TypeString,
+				Description: "IP Address | CIDR | (null implies Any)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of this resource",
+				Computed:    true,
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Description: "NAT action",
+				Computed:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Indicates whether the NAT rule is enabled",
+				Computed:    true,
+			},
+			"logging": {
+				Type:        schema.TypeBool,
+				Description: "Indicates whether logging is enabled for this rule",
+				Computed:    true,
+			},
+			"match_source_network": {
+				Type:        schema.TypeString,
+				Description: "IP Address | CIDR | (null implies Any)",
+				Computed:    true,
+			},
+			"nat_pass": {
+				Type:        schema.TypeBool,
+				Description: "Indicates whether the firewall rules are bypassed for this NAT rule",
+				Computed:    true,
+			},
+			"rule_priority": {
+				Type:        schema.TypeInt,
+				Description: "The priority of the rule",
+				Computed:    true,
+			},
+			"translated_network": {
+				Type:        schema.TypeString,
+				Description: "IP Address | IP Range | CIDR",
+				Computed:    true,
+			},
+			"translated_ports": {
+				Type:        schema.TypeString,
+				Description: "port number or port range. DNAT only",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNsxtNatRuleRead(d *schema.ResourceData, m interface{}) error {
+	// Read a NAT rule of a logical router by name or destination network
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return dataSourceNotSupportedError()
+	}
+
+	routerID := d.Get("logical_router_id").(string)
+	objName := d.Get("display_name").(string)
+	matchDestination := d.Get("match_destination_network").(string)
+	if objName == "" && matchDestination == "" {
+		return fmt.Errorf("Error obtaining NAT rule display_name or match_destination_network during read")
+	}
+
+	var matches []manager.NatRule
+	lister := func(info *paginationInfo) error {
+		objList, _, err := nsxClient.LogicalRoutingAndServicesApi.ListNatRules(nsxClient.Context, routerID, info.LocalVarOptionals)
+		if err != nil {
+			return fmt.Errorf("Error while reading NAT rules of logical router %s: %v", routerID, err)
+		}
+
+		info.PageCount = int64(len(objList.Results))
+		info.TotalCount = objList.ResultCount
+		info.Cursor = objList.Cursor
+
+		for _, objInList := range objList.Results {
+			if objName != "" && objInList.DisplayName != objName {
+				continue
+			}
+			if matchDestination != "" && objInList.MatchDestinationNetwork != matchDestination {
+				continue
+			}
+			matches = append(matches, objInList)
+		}
+		return nil
+	}
+
+	total, err := handlePagination(lister)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		return fmt.Errorf("NAT rule matching the given filter was not found among %d rules of logical router %s", total, routerID)
+	}
+	if len(matches) > 1 {
+		return fmt.Errorf("Found %d NAT rules matching the given filter on logical router %s", len(matches), routerID)
+	}
+	obj := matches[0]
+
+	d.SetId(obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	d.Set("action", obj.Action)
+	d.Set("enabled", obj.Enabled)
+	d.Set("logging", obj.Logging)
+	d.Set("match_destination_network", obj.MatchDestinationNetwork)
+	d.Set("match_source_network", obj.MatchSourceNetwork)
+	d.Set("nat_pass", obj.NatPass)
+	d.Set("rule_priority", obj.RulePriority)
+	d.Set("translated_network", obj.TranslatedNetwork)
+	d.Set("translated_ports", obj.TranslatedPorts)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceNsxtNatRule_basic(t *testing.T) {
+	ruleName := getAccTestDataSourceName()
+	edgeClusterName := getEdgeClusterName()
+	testResourceName := "data.nsxt_nat_rule.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXNatRuleReadTemplate(ruleName, edgeClusterName, "display_name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", ruleName),
+					resource.TestCheckResourceAttr(testResourceName, "action", "DNAT"),
+					resource.TestCheckResourceAttr(testResourceName, "translated_network", "4.4.4.4"),
+					resource.TestCheckResourceAttr(testResourceName, "match_destination_network", "3.3.3.3"),
+					resource.TestCheckResourceAttrPair(testResourceName, "id", "nsxt_nat_rule.test", "id"),
+				),
+			},
+			{
+				Config: testAccNSXNatRuleReadTemplate(ruleName, edgeClusterName, "match_destination_network"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", ruleName),
+					resource.TestCheckResourceAttrPair(testResourceName, "id", "nsxt_nat_rule.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNSXNatRuleReadTemplate(name string, edgeClusterName string, filter string) string {
+	return testAccNSXNATRulePreConditionTemplate(edgeClusterName) + fmt.Sprintf(`
+resource "nsxt_nat_rule" "test" {
+  logical_router_id         = "${nsxt_logical_tier1_router.rtr1.id}"
+  display_name              = "%s"
+  action                    = "DNAT"
+  translated_network        = "4.4.4.4"
+  match_destination_network = "3.3.3.3"
+}
+
+data "nsxt_nat_rule" "test" {
+  logical_router_id = "${nsxt_logical_tier1_router.rtr1.id}"
+  %s = "${nsxt_nat_rule.test.%s}"
+}`, name, filter, filter)
+}
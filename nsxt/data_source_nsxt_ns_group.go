@@ -34,6 +34,21 @@ func dataSourceNsxtNsGroup() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
+			"tag_scope": {
+				Type:        schema.TypeString,
+				Description: "Only consider objects that have a tag with this scope",
+				Optional:    true,
+			},
+			"tag_value": {
+				Type:        schema.TypeString,
+				Description: "Only consider objects that have a tag with this value, together with tag_scope if specified",
+				Optional:    true,
+			},
+			"member_count": {
+				Type:        schema.TypeInt,
+				Description: "Count of the static members in this NS group",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -47,6 +62,16 @@ func dataSourceNsxtNsGroupRead(d *schema.ResourceData, m interface{}) error {
 
 	objID := d.Get("id").(string)
 	objName := d.Get("display_name").(string)
+	tagScope := d.Get("tag_scope").(string)
+	tagValue := d.Get("tag_value").(string)
+	if objID == "" && (tagScope != "" || tagValue != "") {
+		// Objects are filtered by tag with NSX search, and then retrieved by id
+		var err error
+		objID, err = searchObjectIDByNameAndTag(m, "NSGroup", "NS group", objName, tagScope, tagValue)
+		if err != nil {
+			return err
+		}
+	}
 	var obj manager.NsGroup
 	if objID != "" {
 		// Get by id
@@ -100,6 +125,7 @@ func dataSourceNsxtNsGroupRead(d *schema.ResourceData, m interface{}) error {
 	d.SetId(obj.Id)
 	d.Set("display_name", obj.DisplayName)
 	d.Set("description", obj.Description)
+	d.Set("member_count", obj.MemberCount)
 
 	return nil
 }
@@ -34,6 +34,7 @@ func TestAccDataSourceNsxtNsGroup_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(testResourceName, "display_name", groupName),
 					resource.TestCheckResourceAttr(testResourceName, "description", groupName),
+					resource.TestCheckResourceAttr(testResourceName, "member_count", "0"),
 				),
 			},
 		},
@@ -4,13 +4,22 @@
 package nsxt
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/vmware/go-vmware-nsxt"
 	"github.com/vmware/go-vmware-nsxt/manager"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data/serializers/cleanjson"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
 )
 
+var nsServiceTypeValues = []string{"L4PortSetNSService", "ICMPTypeNSService", "IPProtocolNSService", "IGMPTypeNSService", "ALGTypeNSService", "EtherTypeNSService"}
+
 func dataSourceNsxtNsService() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceNsxtNsServiceRead,
@@ -34,10 +43,73 @@ func dataSourceNsxtNsService() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Description:  "Type of the NS service entry",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(nsServiceTypeValues, false),
+			},
+			"l4_protocol": {
+				Type:         schema.TypeString,
+				Description:  "Only consider L4 port set services with this protocol",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(protocolValues, false),
+			},
+			"destination_port": {
+				Type:         schema.TypeInt,
+				Description:  "Only consider L4 port set services with destination ports that include this port",
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
 		},
 	}
 }
 
+// nsServicePortsContain returns whether port is one of ports, which can be
+// single ports or port ranges
+func nsServicePortsContain(ports []string, port int) bool {
+	for _, portStr := range ports {
+		bounds := strings.SplitN(strings.TrimSpace(portStr), "-", 2)
+		low, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			continue
+		}
+		high := low
+		if len(bounds) == 2 {
+			high, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				continue
+			}
+		}
+		if port >= low && port <= high {
+			return true
+		}
+	}
+	return false
+}
+
+// nsServiceEntryMatches checks NS service entry against data source filters.
+// Protocol and port filters only match L4 port set services.
+func nsServiceEntryMatches(entry manager.L4PortSetNsServiceEntry, resourceType string, l4Protocol string, destinationPort int) bool {
+	if resourceType != "" && entry.ResourceType != resourceType {
+		return false
+	}
+	if l4Protocol == "" && destinationPort == 0 {
+		return true
+	}
+	if entry.ResourceType != "L4PortSetNSService" {
+		return false
+	}
+	if l4Protocol != "" && entry.L4Protocol != l4Protocol {
+		return false
+	}
+	if destinationPort != 0 && !nsServicePortsContain(entry.DestinationPorts, destinationPort) {
+		return false
+	}
+	return true
+}
+
 func dataSourceNsxtNsServiceRead(d *schema.ResourceData, m interface{}) error {
 	// Read NS Service by name or id
 	nsxClient := m.(nsxtClients).NsxtClient
@@ -47,6 +119,40 @@ func dataSourceNsxtNsServiceRead(d *schema.ResourceData, m interface{}) error {
 
 	objID := d.Get("id").(string)
 	objName := d.Get("display_name").(string)
+	resourceType := d.Get("resource_type").(string)
+	l4Protocol := d.Get("l4_protocol").(string)
+	destinationPort := d.Get("destination_port").(int)
+	hasFilter := resourceType != "" || l4Protocol != "" || destinationPort != 0
+
+	if objID == "" && hasFilter {
+		// The list API does not return the service entry, hence services are
+		// searched along with their entries
+		services, err := searchNsServices(getPolicyConnector(m), objName, resourceType, l4Protocol)
+		if err != nil {
+			return fmt.Errorf("Error while searching for NS services: %v", err)
+		}
+		var matches []manager.L4PortSetNsService
+		for _, service := range services {
+			// Destination port may be within a range, hence it is not part
+			// of the search query
+			if nsServiceEntryMatches(service.NsserviceElement, resourceType, l4Protocol, destinationPort) {
+				matches = append(matches, service)
+			}
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("NS service matching the filter was not found")
+		}
+		if len(matches) > 1 {
+			return fmt.Errorf("Found multiple NS services matching the filter, please use display_name or additional filters to narrow down the search")
+		}
+
+		d.SetId(matches[0].Id)
+		d.Set("display_name", matches[0].DisplayName)
+		d.Set("description", matches[0].Description)
+		d.Set("resource_type", matches[0].NsserviceElement.ResourceType)
+		return nil
+	}
+
 	var obj manager.NsService
 	if objID != "" {
 		// Get by id
@@ -96,9 +202,85 @@ func dataSourceNsxtNsServiceRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error obtaining NS service ID or name during read")
 	}
 
+	entryObj, _, err := nsxClient.GroupingObjectsApi.ReadL4PortSetNSService(nsxClient.Context, obj.Id)
+	if err != nil {
+		return fmt.Errorf("Error while reading NS service %s: %v", obj.Id, err)
+	}
+	if !nsServiceEntryMatches(entryObj.NsserviceElement, resourceType, l4Protocol, destinationPort) {
+		return fmt.Errorf("NS service %s does not match the filter", obj.Id)
+	}
+
 	d.SetId(obj.Id)
 	d.Set("display_name", obj.DisplayName)
 	d.Set("description", obj.Description)
+	d.Set("resource_type", entryObj.NsserviceElement.ResourceType)
 
 	return nil
 }
+
+// searchNsServices looks up NS services using NSX search, which returns the
+// service entry along with the service. Display name, entry type and L4
+// protocol filters are ignored if empty. Services are decoded as L4 port set
+// services, since this model also holds resource type of other entries.
+func searchNsServices(connector *client.RestConnector, objName string, resourceType string, l4Protocol string) ([]manager.L4PortSetNsService, error) {
+	query := "resource_type:NSService"
+	if objName != "" {
+		query = fmt.Sprintf("%s AND display_name:%s*", query, objName)
+	}
+	if resourceType != "" {
+		query = fmt.Sprintf("%s AND nsservice_element.resource_type:%s", query, resourceType)
+	}
+	if l4Protocol != "" {
+		query = fmt.Sprintf("%s AND nsservice_element.l4_protocol:%s", query, l4Protocol)
+	}
+	resultValues, err := searchLMResources(connector, query)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := cleanjson.NewDataValueToJsonEncoder()
+	var services []manager.L4PortSetNsService
+	for _, result := range resultValues {
+		resultJSON, err := encoder.Encode(result)
+		if err != nil {
+			return nil, err
+		}
+		var service manager.L4PortSetNsService
+		if err := json.Unmarshal([]byte(resultJSON), &service); err != nil {
+			return nil, err
+		}
+		// Search matches display name by prefix
+		if objName != "" && service.DisplayName != objName {
+			continue
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+// listNsServiceIDs returns the IDs of all NS services with the given display
+// name (or of all NS services if name is empty), and the total service count.
+// The list API does not return the service entry, so callers need to read each
+// candidate with the type specific API to check its type and attributes.
+func listNsServiceIDs(nsxClient *api.APIClient, name string) ([]string, int64, error) {
+	var ids []string
+	lister := func(info *paginationInfo) error {
+		objList, _, err := nsxClient.GroupingObjectsApi.ListNSServices(nsxClient.Context, info.LocalVarOptionals)
+		if err != nil {
+			return fmt.Errorf("Error while reading NS services: %v", err)
+		}
+		info.PageCount = int64(len(objList.Results))
+		info.TotalCount = objList.ResultCount
+		info.Cursor = objList.Cursor
+
+		for _, objInList := range objList.Results {
+			if name == "" || objInList.DisplayName == name {
+				ids = append(ids, objInList.Id)
+			}
+		}
+		return nil
+	}
+
+	total, err := handlePagination(lister)
+	return ids, total, err
+}
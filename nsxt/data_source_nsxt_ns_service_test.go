@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -115,6 +116,60 @@ func testAccDataSourceNsxtNsServiceDeleteByName(serviceName string) error {
 	return fmt.Errorf("Error while deleting NS service '%s': service not found", serviceName)
 }
 
+func TestAccDataSourceNsxtNsService_filter(t *testing.T) {
+	testResourceName := "data.nsxt_ns_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nsxt_ns_service" "test" {
+  display_name     = "HTTPS"
+  l4_protocol      = "TCP"
+  destination_port = 443
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testResourceName, "id"),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", "HTTPS"),
+					resource.TestCheckResourceAttr(testResourceName, "resource_type", "L4PortSetNSService"),
+				),
+			},
+			{
+				Config: `
+data "nsxt_ns_service" "test" {
+  display_name  = "HTTPS"
+  resource_type = "ICMPTypeNSService"
+}`,
+				ExpectError: regexp.MustCompile("was not found"),
+			},
+		},
+	})
+}
+
+func TestNsServiceEntryMatches(t *testing.T) {
+	entry := manager.L4PortSetNsServiceEntry{
+		ResourceType:     "L4PortSetNSService",
+		L4Protocol:       "TCP",
+		DestinationPorts: []string{"443", "8000-8080"},
+	}
+	icmpEntry := manager.L4PortSetNsServiceEntry{ResourceType: "ICMPTypeNSService"}
+
+	if !nsServiceEntryMatches(entry, "", "", 0) || !nsServiceEntryMatches(icmpEntry, "", "", 0) {
+		t.Errorf("Expected empty filter to match")
+	}
+	if !nsServiceEntryMatches(entry, "L4PortSetNSService", "TCP", 443) || !nsServiceEntryMatches(entry, "", "", 8080) {
+		t.Errorf("Expected entry %v to match", entry)
+	}
+	if nsServiceEntryMatches(entry, "", "UDP", 0) || nsServiceEntryMatches(entry, "", "", 80) || nsServiceEntryMatches(entry, "ICMPTypeNSService", "", 0) {
+		t.Errorf("Expected entry %v not to match", entry)
+	}
+	if !nsServiceEntryMatches(icmpEntry, "ICMPTypeNSService", "", 0) || nsServiceEntryMatches(icmpEntry, "", "TCP", 0) {
+		t.Errorf("Expected protocol filter to only match L4 port set services")
+	}
+}
+
 func testAccNSXNsServiceReadTemplate(serviceName string) string {
 	return fmt.Sprintf(`
 data "nsxt_ns_service" "test" {
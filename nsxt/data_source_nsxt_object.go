@@ -0,0 +1,192 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func dataSourceNsxtObject() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtObjectRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "Unique ID of the object",
+				Computed:    true,
+			},
+			"display_name": {
+				Type:         schema.TypeString,
+				Description:  "The display name of the object",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Description:  "NSX resource type of the object, such as LogicalSwitch, NSGroup or IPSet",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"tag_scope": {
+				Type:        schema.TypeString,
+				Description: "Only consider objects that have a tag with this scope",
+				Optional:    true,
+			},
+			"tag_value": {
+				Type:        schema.TypeString,
+				Description: "Only consider objects that have a tag with this value, together with tag_scope if specified",
+				Optional:    true,
+			},
+			"description": getDataSourceDescriptionSchema(),
+			"path": {
+				Type:        schema.TypeString,
+				Description: "Policy path of the object, if applicable",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// getObjectSearchFilterDescription describes name and tag filters for error messages
+func getObjectSearchFilterDescription(objName string, tagScope string, tagValue string) string {
+	var filters []string
+	if objName != "" {
+		filters = append(filters, fmt.Sprintf("name '%s'", objName))
+	}
+	if tagScope != "" {
+		filters = append(filters, fmt.Sprintf("tag scope '%s'", tagScope))
+	}
+	if tagValue != "" {
+		filters = append(filters, fmt.Sprintf("tag '%s'", tagValue))
+	}
+	return strings.Join(filters, " and ")
+}
+
+// objectHasTag returns whether one of the tags matches both scope and value.
+// Empty scope or value matches any.
+func objectHasTag(tags []model.Tag, tagScope string, tagValue string) bool {
+	if tagScope == "" && tagValue == "" {
+		return true
+	}
+	for _, tag := range tags {
+		if tagScope != "" && (tag.Scope == nil || *tag.Scope != tagScope) {
+			continue
+		}
+		if tagValue != "" && (tag.Tag == nil || *tag.Tag != tagValue) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// getObjectSearchQuery builds NSX search query for manager objects of given
+// resource type, display name prefix and tag. Tag scope and value are escaped,
+// since those commonly contain characters of the query syntax, such as ':'.
+func getObjectSearchQuery(resourceType string, objName string, tagScope string, tagValue string) string {
+	// Manager objects do not carry marked_for_delete, hence negative condition
+	query := fmt.Sprintf("resource_type:%s AND NOT marked_for_delete:true", resourceType)
+	if objName != "" {
+		query = fmt.Sprintf("%s AND display_name:%s*", query, objName)
+	}
+	if tagScope != "" {
+		query = fmt.Sprintf("%s AND tags.scope:%s", query, escapeSpecialCharacters(tagScope))
+	}
+	if tagValue != "" {
+		query = fmt.Sprintf("%s AND tags.tag:%s", query, escapeSpecialCharacters(tagValue))
+	}
+	return query
+}
+
+// searchObjectsByNameAndTag looks up manager objects of given resource type
+// using NSX search, filtered by exact display name and tag. Each filter is
+// ignored if empty.
+func searchObjectsByNameAndTag(connector *client.RestConnector, resourceType string, objName string, tagScope string, tagValue string) ([]model.PolicyResource, error) {
+	resultValues, err := searchLMResources(connector, getObjectSearchQuery(resourceType, objName, tagScope, tagValue))
+	if err != nil {
+		return nil, err
+	}
+
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+
+	var matches []model.PolicyResource
+	for _, result := range resultValues {
+		dataValue, errors := converter.ConvertToGolang(result, model.PolicyResourceBindingType())
+		if len(errors) > 0 {
+			return nil, errors[0]
+		}
+		obj := dataValue.(model.PolicyResource)
+		if obj.ResourceType == nil || *obj.ResourceType != resourceType {
+			continue
+		}
+		if objName != "" && (obj.DisplayName == nil || *obj.DisplayName != objName) {
+			continue
+		}
+		// Search matches scope and value on any tag, verify both are on same tag
+		if !objectHasTag(obj.Tags, tagScope, tagValue) {
+			continue
+		}
+		matches = append(matches, obj)
+	}
+	return matches, nil
+}
+
+// searchObjectIDByNameAndTag returns ID of the single manager object that
+// matches name and tag filters. This is used by data sources to disambiguate
+// objects with same name by their tags.
+func searchObjectIDByNameAndTag(m interface{}, resourceType string, objDescription string, objName string, tagScope string, tagValue string) (string, error) {
+	matches, err := searchObjectsByNameAndTag(getPolicyConnector(m), resourceType, objName, tagScope, tagValue)
+	filterDescription := getObjectSearchFilterDescription(objName, tagScope, tagValue)
+	if err != nil {
+		return "", fmt.Errorf("Error while searching for %s with %s: %v", objDescription, filterDescription, err)
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("%s with %s was not found", objDescription, filterDescription)
+	}
+	if len(matches) > 1 {
+		return "", fmt.Errorf("Found multiple %ss with %s", objDescription, filterDescription)
+	}
+	return *matches[0].Id, nil
+}
+
+func dataSourceNsxtObjectRead(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	objName := d.Get("display_name").(string)
+	resourceType := d.Get("resource_type").(string)
+	tagScope := d.Get("tag_scope").(string)
+	tagValue := d.Get("tag_value").(string)
+
+	matches, err := searchObjectsByNameAndTag(connector, resourceType, objName, tagScope, tagValue)
+	filterDescription := getObjectSearchFilterDescription(objName, tagScope, tagValue)
+	if err != nil {
+		return fmt.Errorf("Error while searching for %s with %s: %v", resourceType, filterDescription, err)
+	}
+
+	if len(matches) == 0 {
+		return fmt.Errorf("%s with %s was not found", resourceType, filterDescription)
+	}
+	if len(matches) > 1 {
+		if tagScope == "" && tagValue == "" {
+			return fmt.Errorf("Found multiple %s with name '%s', please use tag_scope or tag_value to narrow down the search", resourceType, objName)
+		}
+		return fmt.Errorf("Found multiple %s with %s", resourceType, filterDescription)
+	}
+
+	obj := matches[0]
+	d.SetId(*obj.Id)
+	d.Set("description", obj.Description)
+	d.Set("path", obj.Path)
+
+	return nil
+}
@@ -0,0 +1,96 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func TestAccDataSourceNsxtObject_basic(t *testing.T) {
+	groupName := getAccTestDataSourceName()
+	testResourceName := "data.nsxt_object.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccDataSourceNsxtNsGroupDeleteByName(groupName)
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccDataSourceNsxtNsGroupCreate(groupName); err != nil {
+						panic(err)
+					}
+				},
+				Config: testAccNSXObjectReadTemplate(groupName, "NSGroup"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testResourceName, "id"),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", groupName),
+					resource.TestCheckResourceAttr(testResourceName, "description", groupName),
+				),
+			},
+			{
+				Config:      testAccNSXObjectReadTemplate(groupName, "IPSet"),
+				ExpectError: regexp.MustCompile("was not found"),
+			},
+		},
+	})
+}
+
+func testAccNSXObjectReadTemplate(name string, resourceType string) string {
+	return fmt.Sprintf(`
+data "nsxt_object" "test" {
+  display_name  = "%s"
+  resource_type = "%s"
+}`, name, resourceType)
+}
+
+func TestObjectHasTag(t *testing.T) {
+	scope1 := "scope1"
+	scope2 := "scope2"
+	tag1 := "tag1"
+	tag2 := "tag2"
+	tags := []model.Tag{{Scope: &scope1, Tag: &tag1}, {Scope: &scope2, Tag: &tag2}}
+
+	if !objectHasTag(tags, "", "") {
+		t.Errorf("Expected empty filter to match")
+	}
+	if !objectHasTag(tags, scope1, "") || !objectHasTag(tags, "", tag2) || !objectHasTag(tags, scope2, tag2) {
+		t.Errorf("Expected tags %v to match", tags)
+	}
+	// Scope and value need to be on the same tag
+	if objectHasTag(tags, scope1, tag2) {
+		t.Errorf("Expected scope %s with tag %s not to match", scope1, tag2)
+	}
+	if objectHasTag(nil, scope1, "") {
+		t.Errorf("Expected object without tags not to match")
+	}
+}
+
+func TestGetObjectSearchQuery(t *testing.T) {
+	cases := []struct {
+		objName  string
+		tagScope string
+		tagValue string
+		expected string
+	}{
+		{"", "", "", "resource_type:NSGroup AND NOT marked_for_delete:true"},
+		{"web", "", "", "resource_type:NSGroup AND NOT marked_for_delete:true AND display_name:web*"},
+		{"", "env", "prod", "resource_type:NSGroup AND NOT marked_for_delete:true AND tags.scope:env AND tags.tag:prod"},
+		{"web", "k8s:ns", "team a/(blue)[1]", `resource_type:NSGroup AND NOT marked_for_delete:true AND display_name:web* AND tags.scope:k8s\:ns AND tags.tag:team\ a\/\(blue\)\[1\]`},
+	}
+	for _, c := range cases {
+		query := getObjectSearchQuery("NSGroup", c.objName, c.tagScope, c.tagValue)
+		if query != c.expected {
+			t.Errorf("Expected query %q, got %q", c.expected, query)
+		}
+	}
+}
@@ -11,6 +11,9 @@ import (
 
 var GitCommit string
 
+// ProviderVersion is set at build time and reported in User-Agent header
+var ProviderVersion string
+
 func dataSourceNsxtProviderInfo() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceNsxtProviderInfoRead,
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vmware-nsxt/manager"
 )
 
@@ -29,9 +30,11 @@ func dataSourceNsxtSwitchingProfile() *schema.Resource {
 				Computed:    true,
 			},
 			"resource_type": {
-				Type:        schema.TypeString,
-				Description: "The resource type representing the specific type of this profile",
-				Computed:    true,
+				Type:         schema.TypeString,
+				Description:  "The resource type representing the specific type of this profile",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(switchingProfileTypeValues, false),
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -52,6 +55,7 @@ func dataSourceNsxtSwitchingProfileRead(d *schema.ResourceData, m interface{}) e
 
 	objID := d.Get("id").(string)
 	objName := d.Get("display_name").(string)
+	profileType := d.Get("resource_type").(string)
 	var obj manager.BaseSwitchingProfile
 	if objID != "" {
 		// Get by id
@@ -63,12 +67,18 @@ func dataSourceNsxtSwitchingProfileRead(d *schema.ResourceData, m interface{}) e
 		if err != nil {
 			return fmt.Errorf("Error while reading switching profile %s: %v", objID, err)
 		}
+		if profileType != "" && objGet.ResourceType != profileType {
+			return fmt.Errorf("switching profile %s is of type %s, expected %s", objID, objGet.ResourceType, profileType)
+		}
 		obj = objGet
 	} else if objName != "" {
 		// Get by full name
 		found := false
 		lister := func(info *paginationInfo) error {
 			info.LocalVarOptionals["includeSystemOwned"] = true
+			if profileType != "" {
+				info.LocalVarOptionals["switchingProfileType"] = profileType
+			}
 			objList, _, err := nsxClient.LogicalSwitchingApi.ListSwitchingProfiles(nsxClient.Context, info.LocalVarOptionals)
 			if err != nil {
 				return fmt.Errorf("Error while reading switching profiles: %v", err)
@@ -80,9 +90,9 @@ func dataSourceNsxtSwitchingProfileRead(d *schema.ResourceData, m interface{}) e
 
 			// go over the list to find the correct one
 			for _, objInList := range objList.Results {
-				if objInList.DisplayName == objName {
+				if objInList.DisplayName == objName && (profileType == "" || objInList.ResourceType == profileType) {
 					if found {
-						return fmt.Errorf("Found multiple switching profiles with name '%s'", objName)
+						return fmt.Errorf("Found multiple switching profiles with name '%s', please specify resource_type", objName)
 					}
 					obj = objInList
 					found = true
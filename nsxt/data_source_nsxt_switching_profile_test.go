@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -38,6 +39,18 @@ func TestAccDataSourceNsxtSwitchingProfile_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(testResourceName, "resource_type", profileType),
 				),
 			},
+			{
+				Config: testAccNSXSwitchingProfileReadByTypeTemplate(profileName, profileType),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", profileName),
+					resource.TestCheckResourceAttr(testResourceName, "resource_type", profileType),
+					resource.TestCheckResourceAttrSet(testResourceName, "id"),
+				),
+			},
+			{
+				Config:      testAccNSXSwitchingProfileReadByTypeTemplate(profileName, "SpoofGuardSwitchingProfile"),
+				ExpectError: regexp.MustCompile(`was not found`),
+			},
 		},
 	})
 }
@@ -95,3 +108,11 @@ data "nsxt_switching_profile" "test" {
   display_name = "%s"
 }`, profileName)
 }
+
+func testAccNSXSwitchingProfileReadByTypeTemplate(profileName string, profileType string) string {
+	return fmt.Sprintf(`
+data "nsxt_switching_profile" "test" {
+  display_name  = "%s"
+  resource_type = "%s"
+}`, profileName, profileType)
+}
@@ -10,9 +10,12 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vmware-nsxt/manager"
 )
 
+var transportZoneTransportTypeValues = []string{"OVERLAY", "VLAN"}
+
 func dataSourceNsxtTransportZone() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceNsxtTransportZoneRead,
@@ -43,10 +46,11 @@ func dataSourceNsxtTransportZone() *schema.Resource {
 				Computed:    true,
 			},
 			"transport_type": {
-				Type:        schema.TypeString,
-				Description: "The transport type of this transport zone (OVERLAY or VLAN)",
-				Optional:    true,
-				Computed:    true,
+				Type:         schema.TypeString,
+				Description:  "The transport type of this transport zone (OVERLAY or VLAN)",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(transportZoneTransportTypeValues, false),
 			},
 		},
 	}
@@ -61,6 +65,7 @@ func dataSourceNsxtTransportZoneRead(d *schema.ResourceData, m interface{}) erro
 
 	objID := d.Get("id").(string)
 	objName := d.Get("display_name").(string)
+	transportType := d.Get("transport_type").(string)
 	var obj manager.TransportZone
 	if objID != "" {
 		// Get by id
@@ -72,39 +77,61 @@ func dataSourceNsxtTransportZoneRead(d *schema.ResourceData, m interface{}) erro
 		if err != nil {
 			return fmt.Errorf("Error while reading transport zone %s: %v", objID, err)
 		}
+		if transportType != "" && objGet.TransportType != transportType {
+			return fmt.Errorf("Transport zone %s is of type %s, expected %s", objID, objGet.TransportType, transportType)
+		}
 		obj = objGet
 	} else if objName == "" {
 		return fmt.Errorf("Error obtaining transport zone ID or name during read")
 	} else {
 		// Get by full name/prefix
-		// TODO use 2nd parameter localVarOptionals for paging
-		objList, _, err := nsxClient.NetworkTransportApi.ListTransportZones(nsxClient.Context, nil)
-		if err != nil {
-			return fmt.Errorf("Error while reading transport zones: %v", err)
-		}
 		// go over the list to find the correct one (prefer a perfect match. If not - prefix match)
 		var perfectMatch []manager.TransportZone
 		var prefixMatch []manager.TransportZone
-		for _, objInList := range objList.Results {
-			if strings.HasPrefix(objInList.DisplayName, objName) {
-				prefixMatch = append(prefixMatch, objInList)
+		lister := func(info *paginationInfo) error {
+			objList, _, err := nsxClient.NetworkTransportApi.ListTransportZones(nsxClient.Context, info.LocalVarOptionals)
+			if err != nil {
+				return fmt.Errorf("Error while reading transport zones: %v", err)
 			}
-			if objInList.DisplayName == objName {
-				perfectMatch = append(perfectMatch, objInList)
+
+			info.PageCount = int64(len(objList.Results))
+			info.TotalCount = objList.ResultCount
+			info.Cursor = objList.Cursor
+
+			for _, objInList := range objList.Results {
+				if transportType != "" && objInList.TransportType != transportType {
+					continue
+				}
+				if strings.HasPrefix(objInList.DisplayName, objName) {
+					prefixMatch = append(prefixMatch, objInList)
+				}
+				if objInList.DisplayName == objName {
+					perfectMatch = append(perfectMatch, objInList)
+				}
 			}
+			return nil
+		}
+
+		_, err := handlePagination(lister)
+		if err != nil {
+			return err
+		}
+		typeDesc := ""
+		if transportType != "" {
+			typeDesc = fmt.Sprintf(" and transport type %s", transportType)
 		}
 		if len(perfectMatch) > 0 {
 			if len(perfectMatch) > 1 {
-				return fmt.Errorf("Found multiple transport zones with name '%s'", objName)
+				return fmt.Errorf("Found multiple transport zones with name '%s'%s", objName, typeDesc)
 			}
 			obj = perfectMatch[0]
 		} else if len(prefixMatch) > 0 {
 			if len(prefixMatch) > 1 {
-				return fmt.Errorf("Found multiple transport zones with name starting with '%s'", objName)
+				return fmt.Errorf("Found multiple transport zones with name starting with '%s'%s", objName, typeDesc)
 			}
 			obj = prefixMatch[0]
 		} else {
-			return fmt.Errorf("Transport zone with name '%s' was not found", objName)
+			return fmt.Errorf("Transport zone with name '%s'%s was not found", objName, typeDesc)
 		}
 	}
 
@@ -5,6 +5,7 @@ package nsxt
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -31,9 +32,42 @@ func TestAccDataSourceNsxtTransportZone_basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceNsxtTransportZone_byType(t *testing.T) {
+	transportZoneName := getVlanTransportZoneName()
+	testResourceName := "data.nsxt_transport_zone.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXTransportZoneReadByTypeTemplate(transportZoneName, "VLAN"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "display_name", transportZoneName),
+					resource.TestCheckResourceAttr(testResourceName, "transport_type", "VLAN"),
+					resource.TestCheckResourceAttrSet(testResourceName, "id"),
+					resource.TestCheckResourceAttrSet(testResourceName, "host_switch_name"),
+				),
+			},
+			{
+				Config:      testAccNSXTransportZoneReadByTypeTemplate(transportZoneName, "OVERLAY"),
+				ExpectError: regexp.MustCompile(`was not found`),
+			},
+		},
+	})
+}
+
 func testAccNSXTransportZoneReadTemplate(transportZoneName string) string {
 	return fmt.Sprintf(`
 data "nsxt_transport_zone" "test" {
   display_name = "%s"
 }`, transportZoneName)
 }
+
+func testAccNSXTransportZoneReadByTypeTemplate(transportZoneName string, transportType string) string {
+	return fmt.Sprintf(`
+data "nsxt_transport_zone" "test" {
+  display_name   = "%s"
+  transport_type = "%s"
+}`, transportZoneName, transportType)
+}
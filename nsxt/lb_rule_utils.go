@@ -224,12 +224,17 @@ func getLbRuleHeaderRewriteActionSchema(optional bool) *schema.Schema {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"name": {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:         schema.TypeString,
+					Description:  "Name of HTTP header. Variables are not supported",
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
 				},
+				// Value is passed to NSX as is, since it may contain captured
+				// or built-in variables, such as $1 or $_ssl_client_cert
 				"value": {
-					Type:     schema.TypeString,
-					Optional: true,
+					Type:        schema.TypeString,
+					Description: "Value of HTTP header, may contain captured and built-in variables",
+					Optional:    true,
 				},
 			},
 		},
@@ -258,6 +263,15 @@ func initLbHTTPRuleMatchConditionFromSchema(data map[string]interface{}, conditi
 	return condition
 }
 
+// getLbRuleCaseSensitive returns case sensitivity of the condition, which
+// defaults to true on NSX side when not returned
+func getLbRuleCaseSensitive(condition loadbalancer.LbRuleCondition) bool {
+	if condition.CaseSensitive == nil {
+		return true
+	}
+	return *condition.CaseSensitive
+}
+
 func fillLbHTTPRuleHeaderConditionInSchema(data map[string]interface{}, condition loadbalancer.LbRuleCondition, isCookie bool) {
 	if isCookie {
 		data["name"] = condition.CookieName
@@ -268,7 +282,7 @@ func fillLbHTTPRuleHeaderConditionInSchema(data map[string]interface{}, conditio
 	}
 	data["inverse"] = condition.Inverse
 	data["match_type"] = condition.MatchType
-	data["case_sensitive"] = *condition.CaseSensitive
+	data["case_sensitive"] = getLbRuleCaseSensitive(condition)
 }
 
 func resourceNsxtLbHTTPRuleDelete(d *schema.ResourceData, m interface{}) error {
@@ -16,19 +16,21 @@ import (
 // Helpers for common LB monitor schema settings
 func getLbMonitorFallCountSchema() *schema.Schema {
 	return &schema.Schema{
-		Type:        schema.TypeInt,
-		Description: "Number of consecutive checks that must fail before marking it down",
-		Optional:    true,
-		Default:     3,
+		Type:         schema.TypeInt,
+		Description:  "Number of consecutive checks that must fail before marking it down",
+		Optional:     true,
+		Default:      3,
+		ValidateFunc: validation.IntAtLeast(1),
 	}
 }
 
 func getLbMonitorIntervalSchema() *schema.Schema {
 	return &schema.Schema{
-		Type:        schema.TypeInt,
-		Description: "The frequency at which the system issues the monitor check (in seconds)",
-		Optional:    true,
-		Default:     5,
+		Type:         schema.TypeInt,
+		Description:  "The frequency at which the system issues the monitor check (in seconds)",
+		Optional:     true,
+		Default:      5,
+		ValidateFunc: validation.IntAtLeast(1),
 	}
 }
 
@@ -43,19 +45,23 @@ func getLbMonitorPortSchema() *schema.Schema {
 
 func getLbMonitorRiseCountSchema() *schema.Schema {
 	return &schema.Schema{
-		Type:        schema.TypeInt,
-		Description: "Number of consecutive checks that must pass before marking it up",
-		Optional:    true,
-		Default:     3,
+		Type:         schema.TypeInt,
+		Description:  "Number of consecutive checks that must pass before marking it up",
+		Optional:     true,
+		Default:      3,
+		ValidateFunc: validation.IntAtLeast(1),
 	}
 }
 
+// Timeout is not validated against interval, since NSX allows the timeout to
+// exceed the interval (default timeout is 15 seconds with 5 seconds interval)
 func getLbMonitorTimeoutSchema() *schema.Schema {
 	return &schema.Schema{
-		Type:        schema.TypeInt,
-		Description: "Number of seconds the target has to respond to the monitor request",
-		Optional:    true,
-		Default:     15,
+		Type:         schema.TypeInt,
+		Description:  "Number of seconds the target has to respond to the monitor request",
+		Optional:     true,
+		Default:      15,
+		ValidateFunc: validation.IntAtLeast(1),
 	}
 }
 
@@ -107,10 +113,10 @@ func getLbMonitorResponseBodySchema() *schema.Schema {
 func getLbMonitorResponseStatusCodesSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeList,
-		Description: "The HTTP response status code should be a valid HTTP status code",
+		Description: "The HTTP response status codes considered healthy, in range 100-599",
 		Elem: &schema.Schema{
 			Type:         schema.TypeInt,
-			ValidateFunc: validation.IntBetween(100, 505),
+			ValidateFunc: validation.IntBetween(100, 599),
 		},
 		Optional: true,
 		Computed: true,
@@ -0,0 +1,92 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// managerAPIError is the error structure returned in body of failed NSX Manager API calls
+type managerAPIError struct {
+	ErrorCode     int64             `json:"error_code,omitempty"`
+	ErrorMessage  string            `json:"error_message,omitempty"`
+	ModuleName    string            `json:"module_name,omitempty"`
+	RelatedErrors []managerAPIError `json:"related_errors,omitempty"`
+}
+
+func (apiError managerAPIError) String() string {
+	if apiError.ErrorMessage != "" && apiError.ErrorCode != 0 {
+		return fmt.Sprintf("%s (code %v)", apiError.ErrorMessage, apiError.ErrorCode)
+	}
+
+	if apiError.ErrorMessage != "" {
+		return apiError.ErrorMessage
+	}
+
+	return fmt.Sprintf("(code %v)", apiError.ErrorCode)
+}
+
+// parseManagerAPIError extracts NSX error from error returned by the manager SDK.
+// The SDK only includes response body for status codes 400 and 500, in form
+// "Status: <status>, Body: <body>".
+func parseManagerAPIError(err error) *managerAPIError {
+	bodyMarker := ", Body: "
+	errStr := err.Error()
+	index := strings.Index(errStr, bodyMarker)
+	if index < 0 {
+		return nil
+	}
+
+	var apiError managerAPIError
+	jsonErr := json.Unmarshal([]byte(errStr[index+len(bodyMarker):]), &apiError)
+	if jsonErr != nil || (apiError.ErrorCode == 0 && apiError.ErrorMessage == "") {
+		return nil
+	}
+
+	return &apiError
+}
+
+// handleManagerAPIError returns an error with NSX error code and message,
+// including related errors, if those can be extracted from err
+func handleManagerAPIError(message string, err error) error {
+	apiError := parseManagerAPIError(err)
+	if apiError == nil {
+		return fmt.Errorf("%s: %v", message, err)
+	}
+
+	details := fmt.Sprintf("%s: %s", message, apiError)
+	if len(apiError.RelatedErrors) > 0 {
+		details += "\nRelated errors:\n"
+		for _, relatedErr := range apiError.RelatedErrors {
+			details += fmt.Sprintf("%s\n", relatedErr)
+		}
+	}
+	log.Printf("[ERROR]: %s", details)
+	return errors.New(details)
+}
+
+// isAmbiguousManagerAPIError returns whether failed request might still have
+// been applied on NSX: no response was received, for example on transport
+// error or timeout, or server failed without reporting an NSX error
+func isAmbiguousManagerAPIError(resp *http.Response, err error) bool {
+	if resp == nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError && parseManagerAPIError(err) == nil
+}
+
+// isNsServiceTypeMismatchResponse returns whether reading an NS service as a
+// specific service type failed because the service is of another type, as
+// opposed to failures such as authentication or server errors
+func isNsServiceTypeMismatchResponse(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+	return resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound
+}
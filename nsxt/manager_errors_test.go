@@ -0,0 +1,84 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestHandleManagerAPIError(t *testing.T) {
+	body := `{"httpStatus":"BAD_REQUEST","error_code":8210,"module_name":"common-services","error_message":"Invalid rule",` +
+		`"related_errors":[{"error_code":8211,"error_message":"Invalid source"}]}`
+	err := handleManagerAPIError("Error during NatRule create", fmt.Errorf("Status: 400 Bad Request, Body: %s", body))
+	expected := "Error during NatRule create: Invalid rule (code 8210)\nRelated errors:\nInvalid source (code 8211)\n"
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+
+	// Error message of NSX is not interpreted as format string
+	body = `{"error_code":8210,"error_message":"Rule priority must be within 0-100%",` +
+		`"related_errors":[{"error_code":8211,"error_message":"Invalid source %s"}]}`
+	err = handleManagerAPIError("Error during NatRule create", fmt.Errorf("Status: 400 Bad Request, Body: %s", body))
+	expected = "Error during NatRule create: Rule priority must be within 0-100% (code 8210)\nRelated errors:\nInvalid source %s (code 8211)\n"
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+
+	err = handleManagerAPIError("Error during NatRule create", fmt.Errorf("404 Not Found"))
+	expected = "Error during NatRule create: 404 Not Found"
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+
+	err = handleManagerAPIError("Error during NatRule create", fmt.Errorf("Status: 500 Internal Server Error, Body: <html></html>"))
+	expected = "Error during NatRule create: Status: 500 Internal Server Error, Body: <html></html>"
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestIsAmbiguousManagerAPIError(t *testing.T) {
+	nsxError := fmt.Errorf(`Status: 500 Internal Server Error, Body: {"error_code":8210,"error_message":"Invalid rule"}`)
+	cases := []struct {
+		resp      *http.Response
+		err       error
+		ambiguous bool
+	}{
+		{nil, fmt.Errorf("context deadline exceeded"), true},
+		{&http.Response{StatusCode: http.StatusBadGateway}, fmt.Errorf("502 Bad Gateway"), true},
+		{&http.Response{StatusCode: http.StatusInternalServerError}, fmt.Errorf("Status: 500 Internal Server Error, Body: <html></html>"), true},
+		{&http.Response{StatusCode: http.StatusInternalServerError}, nsxError, false},
+		{&http.Response{StatusCode: http.StatusBadRequest}, fmt.Errorf("Status: 400 Bad Request, Body: "), false},
+	}
+	for _, c := range cases {
+		if isAmbiguousManagerAPIError(c.resp, c.err) != c.ambiguous {
+			t.Errorf("Expected ambiguous %v for error %v", c.ambiguous, c.err)
+		}
+	}
+}
+
+func TestIsNsServiceTypeMismatchResponse(t *testing.T) {
+	cases := []struct {
+		resp     *http.Response
+		mismatch bool
+	}{
+		{nil, false},
+		{&http.Response{StatusCode: http.StatusBadRequest}, true},
+		{&http.Response{StatusCode: http.StatusNotFound}, true},
+		{&http.Response{StatusCode: http.StatusUnauthorized}, false},
+		{&http.Response{StatusCode: http.StatusForbidden}, false},
+		{&http.Response{StatusCode: http.StatusServiceUnavailable}, false},
+	}
+	for _, c := range cases {
+		status := 0
+		if c.resp != nil {
+			status = c.resp.StatusCode
+		}
+		if isNsServiceTypeMismatchResponse(c.resp) != c.mismatch {
+			t.Errorf("Expected type mismatch %v for status %d", c.mismatch, status)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package nsxt
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -350,9 +351,23 @@ func getPolicySecurityPolicySchema(isIds bool) map[string]*schema.Schema {
 	return result
 }
 
+// sortPolicyRulesBySequenceNumber orders rules the way they are evaluated,
+// since NSX does not guarantee rules to be returned in this order. Rules
+// without sequence number are kept at the end.
+func sortPolicyRulesBySequenceNumber(rules []model.Rule) []model.Rule {
+	sorted := append([]model.Rule{}, rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].SequenceNumber == nil || sorted[j].SequenceNumber == nil {
+			return sorted[j].SequenceNumber == nil && sorted[i].SequenceNumber != nil
+		}
+		return *sorted[i].SequenceNumber < *sorted[j].SequenceNumber
+	})
+	return sorted
+}
+
 func setPolicyRulesInSchema(d *schema.ResourceData, rules []model.Rule) error {
 	var rulesList []map[string]interface{}
-	for _, rule := range rules {
+	for _, rule := range sortPolicyRulesBySequenceNumber(rules) {
 		elem := make(map[string]interface{})
 		elem["display_name"] = rule.DisplayName
 		elem["description"] = rule.Description
@@ -483,6 +498,25 @@ func parseGatewayPolicyPath(gwPath string) (bool, string) {
 	return isT0, segs[len(segs)-1]
 }
 
+// nsxtPolicyGatewayImporter returns importer that accepts either gateway ID
+// or policy path of the gateway, gwType being "tier-0s" or "tier-1s"
+func nsxtPolicyGatewayImporter(gwType string) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		importID := d.Id()
+		if !isPolicyPath(importID) {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		gwID := getResourceIDFromResourcePath(importID, gwType)
+		if gwID == "" || getPolicyIDFromPath(importID) != gwID {
+			return nil, fmt.Errorf("Import path %s does not point to a gateway under %s", importID, gwType)
+		}
+		d.SetId(gwID)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
 func getPolicyPathSchema(isRequired bool, forceNew bool, description string) *schema.Schema {
 	return &schema.Schema{
 		Type:         schema.TypeString,
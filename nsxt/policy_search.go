@@ -120,8 +120,9 @@ func listPolicyResourcesByNameAndType(connector *client.RestConnector, isGlobalM
 }
 
 func escapeSpecialCharacters(str string) string {
-	// we replace special characters that can be encountered in object IDs
-	specials := "()[]"
+	// we replace special characters that can be encountered in object IDs,
+	// as well as in tag scopes and values
+	specials := "()[]:/ "
 	if !strings.ContainsAny(str, specials) {
 		return str
 	}
@@ -183,14 +184,18 @@ func searchGMPolicyResources(connector *client.RestConnector, query string) ([]*
 }
 
 func searchLMPolicyResources(connector *client.RestConnector, query string) ([]*data.StructValue, error) {
+	// Make sure global objects are not found (path needs to start with infra)
+	return searchLMResources(connector, query+" AND path:\\/infra*")
+}
+
+// searchLMResources runs search query on local manager, results may include
+// both policy and manager objects
+func searchLMResources(connector *client.RestConnector, query string) ([]*data.StructValue, error) {
 	client := lm_search.NewQueryClient(connector)
 	var results []*data.StructValue
 	var cursor *string
 	total := 0
 
-	// Make sure global objects are not found (path needs to start with infra)
-	query = query + " AND path:\\/infra*"
-
 	for {
 		searchResponse, err := client.List(query, cursor, nil, nil, nil, nil)
 		if err != nil {
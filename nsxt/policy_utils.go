@@ -158,6 +158,28 @@ func nsxtDomainResourceImporter(d *schema.ResourceData, m interface{}) ([]*schem
 	return []*schema.ResourceData{d}, nil
 }
 
+// nsxtDomainResourcePathImporter returns importer that accepts policy path of
+// the object, in addition to ID or domain/ID, rType being the path segment
+// of the object type, such as "groups"
+func nsxtDomainResourcePathImporter(rType string) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		importID := d.Id()
+		if !isPolicyPath(importID) {
+			return nsxtDomainResourceImporter(d, m)
+		}
+
+		domain := getResourceIDFromResourcePath(importID, "domains")
+		id := getResourceIDFromResourcePath(importID, rType)
+		if domain == "" || id == "" || getPolicyIDFromPath(importID) != id {
+			return nil, fmt.Errorf("Import path %s does not point to an object under domains/<domain>/%s", importID, rType)
+		}
+		d.SetId(id)
+		d.Set("domain", domain)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
 func isPolicyPath(policyPath string) bool {
 	pathSegs := strings.Split(policyPath, "/")
 	if len(pathSegs) < 4 {
@@ -4,6 +4,7 @@
 package nsxt
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -11,11 +12,14 @@ import (
 	"io/ioutil"
 	"log"
 	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	api "github.com/vmware/go-vmware-nsxt"
@@ -26,7 +30,10 @@ import (
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/security"
 )
 
-var defaultRetryOnStatusCodes = []int{400, 409, 429, 500, 503, 504}
+var defaultRetryOnStatusCodes = []int{400, 409, 429, 500, 502, 503, 504}
+
+// Idle connections pool size of the manager SDK default transport
+const defaultMaxIdleConns = 100
 
 // Provider configuration that is shared for policy and MP
 type commonProviderConfig struct {
@@ -37,6 +44,13 @@ type commonProviderConfig struct {
 	MinRetryInterval       int
 	MaxRetryInterval       int
 	RetryStatusCodes       []int
+	AutoRetryOnConflict    bool
+	// Shared by MP and policy clients, nil if requests are not limited
+	RequestLimiter *requestLimiter
+	// Proxy selection for MP and policy clients
+	Proxy func(*http.Request) (*url.URL, error)
+	// User-Agent header for MP and policy clients
+	UserAgent string
 }
 
 type nsxtClients struct {
@@ -59,7 +73,7 @@ type nsxtClients struct {
 
 // Provider for VMWare NSX-T
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 
 		Schema: map[string]*schema.Schema{
 			"allow_unverified_ssl": {
@@ -78,6 +92,12 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("NSXT_PASSWORD", nil),
 				Sensitive:   true,
 			},
+			"session_auth": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Authenticate with a session that is created once and renewed on expiry, instead of per request",
+				DefaultFunc: schema.EnvDefaultFunc("NSXT_SESSION_AUTH", false),
+			},
 			"remote_auth": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -92,11 +112,13 @@ func Provider() *schema.Provider {
 			},
 			"client_auth_cert_file": {
 				Type:        schema.TypeString,
+				Description: "Client certificate file passed in PEM format",
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NSXT_CLIENT_AUTH_CERT_FILE", nil),
 			},
 			"client_auth_key_file": {
 				Type:        schema.TypeString,
+				Description: "Client certificate key file passed in PEM format",
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NSXT_CLIENT_AUTH_KEY_FILE", nil),
 			},
@@ -132,12 +154,47 @@ func Provider() *schema.Provider {
 				},
 				// There is no support for default values/func for list, so it will be handled later
 			},
+			"max_requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of API requests per second sent to NSX. 0 means unlimited",
+				DefaultFunc:  schema.EnvDefaultFunc("NSXT_MAX_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"auto_retry_on_conflict": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Refresh the revision and retry updates rejected due to a concurrent modification",
+				DefaultFunc: schema.EnvDefaultFunc("NSXT_AUTO_RETRY_ON_CONFLICT", false),
+			},
 			"tolerate_partial_success": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Description: "Treat partial success status as success",
 				DefaultFunc: schema.EnvDefaultFunc("NSXT_TOLERATE_PARTIAL_SUCCESS", false),
 			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of HTTP or HTTPS proxy to reach NSX manager through. HTTP_PROXY and HTTPS_PROXY environment variables are used if not set",
+				DefaultFunc:  schema.EnvDefaultFunc("NSXT_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
+			"proxy_username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "User name for proxy authentication",
+				DefaultFunc:  schema.EnvDefaultFunc("NSXT_PROXY_USERNAME", nil),
+				RequiredWith: []string{"proxy_url"},
+			},
+			"proxy_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "Password for proxy authentication",
+				DefaultFunc:  schema.EnvDefaultFunc("NSXT_PROXY_PASSWORD", nil),
+				RequiredWith: []string{"proxy_username"},
+			},
 			"vmc_auth_host": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -200,23 +257,73 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NSXT_CA", nil),
 			},
+			"server_thumbprint": {
+				Type:         schema.TypeString,
+				Description:  "SHA-256 thumbprint of NSX manager certificate. If set, only a certificate matching this thumbprint is accepted",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NSXT_SERVER_THUMBPRINT", nil),
+				ValidateFunc: validation.StringMatch(serverThumbprintRegexp, "Must be a SHA-256 thumbprint of 64 hex characters, optionally separated by colons"),
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Description: "Custom suffix for User-Agent header sent with NSX API requests, in order to attribute API traffic",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NSXT_USER_AGENT_SUFFIX", nil),
+			},
+			"manager_api_version": {
+				Type:         schema.TypeString,
+				Description:  "NSX version to assume when selecting API code paths, instead of detecting it from NSX manager",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NSXT_MANAGER_API_VERSION", nil),
+				ValidateFunc: validation.StringMatch(managerAPIVersionRegexp, "Must be a version in the form of major.minor or major.minor.patch, for example 3.0.2"),
+			},
+			"connection_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Timeout in seconds for establishing connection to NSX manager, including TLS handshake. 0 means no timeout",
+				DefaultFunc:  schema.EnvDefaultFunc("NSXT_CONNECTION_TIMEOUT", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of idle connections to NSX manager kept open for reuse",
+				DefaultFunc:  schema.EnvDefaultFunc("NSXT_MAX_IDLE_CONNS", defaultMaxIdleConns),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"idle_conn_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Time in seconds an idle connection to NSX manager is kept open for reuse. 0 means no limit",
+				DefaultFunc:  schema.EnvDefaultFunc("NSXT_IDLE_CONN_TIMEOUT", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"nsxt_provider_info":                    dataSourceNsxtProviderInfo(),
+			"nsxt_manager_info":                     dataSourceNsxtManagerInfo(),
 			"nsxt_transport_zone":                   dataSourceNsxtTransportZone(),
 			"nsxt_switching_profile":                dataSourceNsxtSwitchingProfile(),
 			"nsxt_logical_tier0_router":             dataSourceNsxtLogicalTier0Router(),
 			"nsxt_logical_tier1_router":             dataSourceNsxtLogicalTier1Router(),
+			"nsxt_logical_router":                   dataSourceNsxtLogicalRouter(),
 			"nsxt_mac_pool":                         dataSourceNsxtMacPool(),
 			"nsxt_ns_group":                         dataSourceNsxtNsGroup(),
+			"nsxt_ip_set":                           dataSourceNsxtIPSet(),
+			"nsxt_mac_set":                          dataSourceNsxtMacSet(),
 			"nsxt_ns_groups":                        dataSourceNsxtNsGroups(),
+			"nsxt_object":                           dataSourceNsxtObject(),
 			"nsxt_ns_service":                       dataSourceNsxtNsService(),
 			"nsxt_ns_services":                      dataSourceNsxtNsServices(),
+			"nsxt_l4_port_set_ns_service":           dataSourceNsxtL4PortSetNsService(),
+			"nsxt_ip_protocol_ns_service":           dataSourceNsxtIPProtocolNsService(),
 			"nsxt_edge_cluster":                     dataSourceNsxtEdgeCluster(),
 			"nsxt_certificate":                      dataSourceNsxtCertificate(),
 			"nsxt_ip_pool":                          dataSourceNsxtIPPool(),
 			"nsxt_firewall_section":                 dataSourceNsxtFirewallSection(),
+			"nsxt_default_firewall_section":         dataSourceNsxtDefaultFirewallSection(),
+			"nsxt_nat_rule":                         dataSourceNsxtNatRule(),
 			"nsxt_management_cluster":               dataSourceNsxtManagementCluster(),
 			"nsxt_policy_edge_cluster":              dataSourceNsxtPolicyEdgeCluster(),
 			"nsxt_policy_edge_node":                 dataSourceNsxtPolicyEdgeNode(),
@@ -286,12 +393,16 @@ func Provider() *schema.Provider {
 			"nsxt_ns_service_group":                        resourceNsxtNsServiceGroup(),
 			"nsxt_ns_group":                                resourceNsxtNsGroup(),
 			"nsxt_firewall_section":                        resourceNsxtFirewallSection(),
+			"nsxt_firewall_section_ordering":               resourceNsxtFirewallSectionOrdering(),
 			"nsxt_nat_rule":                                resourceNsxtNatRule(),
+			"nsxt_nat_rule_order":                          resourceNsxtNatRuleOrder(),
+			"nsxt_support_bundle":                          resourceNsxtSupportBundle(),
 			"nsxt_ip_block":                                resourceNsxtIPBlock(),
 			"nsxt_ip_block_subnet":                         resourceNsxtIPBlockSubnet(),
 			"nsxt_ip_pool":                                 resourceNsxtIPPool(),
 			"nsxt_ip_pool_allocation_ip_address":           resourceNsxtIPPoolAllocationIPAddress(),
 			"nsxt_ip_set":                                  resourceNsxtIPSet(),
+			"nsxt_mac_set":                                 resourceNsxtMacSet(),
 			"nsxt_static_route":                            resourceNsxtStaticRoute(),
 			"nsxt_vm_tags":                                 resourceNsxtVMTags(),
 			"nsxt_lb_icmp_monitor":                         resourceNsxtLbIcmpMonitor(),
@@ -358,6 +469,7 @@ func Provider() *schema.Provider {
 			"nsxt_policy_intrusion_service_profile":        resourceNsxtPolicyIntrusionServiceProfile(),
 			"nsxt_policy_evpn_tenant":                      resourceNsxtPolicyEvpnTenant(),
 			"nsxt_policy_evpn_config":                      resourceNsxtPolicyEvpnConfig(),
+			"nsxt_policy_tier0_security_config":            resourceNsxtPolicyTier0SecurityConfig(),
 			"nsxt_policy_evpn_tunnel_endpoint":             resourceNsxtPolicyEvpnTunnelEndpoint(),
 			"nsxt_policy_qos_profile":                      resourceNsxtPolicyQosProfile(),
 			"nsxt_policy_ospf_config":                      resourceNsxtPolicyOspfConfig(),
@@ -371,6 +483,78 @@ func Provider() *schema.Provider {
 
 		ConfigureFunc: providerConfigure,
 	}
+
+	addTagScopesValidation(provider.ResourcesMap)
+
+	return provider
+}
+
+var serverThumbprintRegexp = regexp.MustCompile("^([0-9a-fA-F]{2}:?){31}[0-9a-fA-F]{2}$")
+
+var managerAPIVersionRegexp = regexp.MustCompile(`^\d+\.\d+(\.\d+)?$`)
+
+func validateServerVerificationSettings(d *schema.ResourceData) error {
+	insecure := d.Get("allow_unverified_ssl").(bool)
+	thumbprint := d.Get("server_thumbprint").(string)
+
+	if insecure && len(thumbprint) > 0 {
+		return fmt.Errorf("Please provide either allow_unverified_ssl or server_thumbprint, not both")
+	}
+	return nil
+}
+
+func normalizeThumbprint(thumbprint string) string {
+	return strings.ToLower(strings.Replace(thumbprint, ":", "", -1))
+}
+
+// setServerThumbprintVerification replaces certificate chain verification with
+// comparison of server certificate SHA-256 thumbprint to the pinned value
+func setServerThumbprintVerification(tlsConfig *tls.Config, thumbprint string) {
+	expected := normalizeThumbprint(thumbprint)
+	tlsConfig.InsecureSkipVerify = true
+	tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("NSX manager did not present a certificate")
+		}
+		actual := fmt.Sprintf("%x", sha256.Sum256(rawCerts[0]))
+		if actual != expected {
+			return fmt.Errorf("NSX manager certificate thumbprint %s does not match server_thumbprint", actual)
+		}
+		return nil
+	}
+}
+
+// setTransportConnectionSettings applies connection establishment timeout and
+// idle connections reuse settings to the transport
+func setTransportConnectionSettings(tr *http.Transport, connectionTimeout int, maxIdleConns int, idleConnTimeout int) {
+	if connectionTimeout > 0 {
+		timeout := time.Duration(connectionTimeout) * time.Second
+		dialer := &net.Dialer{Timeout: timeout}
+		tr.DialContext = dialer.DialContext
+		tr.TLSHandshakeTimeout = timeout
+	}
+	// All requests go to a single host
+	tr.MaxIdleConns = maxIdleConns
+	tr.MaxIdleConnsPerHost = maxIdleConns
+	tr.IdleConnTimeout = time.Duration(idleConnTimeout) * time.Second
+}
+
+func validateClientAuthSettings(d *schema.ResourceData) error {
+	clientAuthCertFile := d.Get("client_auth_cert_file").(string)
+	clientAuthKeyFile := d.Get("client_auth_key_file").(string)
+	clientAuthCert := d.Get("client_auth_cert").(string)
+	clientAuthKey := d.Get("client_auth_key").(string)
+
+	if len(clientAuthKeyFile) > 0 && len(clientAuthCertFile) == 0 {
+		return fmt.Errorf("Please provide client certificate file for the key file")
+	}
+	if len(clientAuthKey) > 0 && len(clientAuthCert) == 0 {
+		return fmt.Errorf("Please provide client certificate for the key")
+	}
+	if len(clientAuthCertFile) > 0 && len(clientAuthCert) > 0 {
+		return fmt.Errorf("Please provide client certificate either as a file or as a string, not both")
+	}
+	return nil
 }
 
 func configureNsxtClient(d *schema.ResourceData, clients *nsxtClients) error {
@@ -381,6 +565,10 @@ func configureNsxtClient(d *schema.ResourceData, clients *nsxtClients) error {
 	vmcToken := d.Get("vmc_token").(string)
 	vmcAuthMode := d.Get("vmc_auth_mode").(string)
 
+	if err := validateClientAuthSettings(d); err != nil {
+		return err
+	}
+
 	if (len(vmcToken) > 0) || (vmcAuthMode == "Basic") {
 		// VMC can operate without token with basic auth, however MP API is not
 		// available for cloud admin user
@@ -439,7 +627,7 @@ func configureNsxtClient(d *schema.ResourceData, clients *nsxtClients) error {
 		BasePath:             "/api/v1",
 		Host:                 host,
 		Scheme:               "https",
-		UserAgent:            "terraform-provider-nsxt",
+		UserAgent:            clients.CommonConfig.UserAgent,
 		UserName:             username,
 		Password:             password,
 		RemoteAuth:           clients.CommonConfig.RemoteAuth,
@@ -453,6 +641,47 @@ func configureNsxtClient(d *schema.ResourceData, clients *nsxtClients) error {
 		RetriesConfiguration: retriesConfig,
 	}
 
+	// With session_auth, session for basic auth users is managed by the
+	// transport, which renews it on expiry. Otherwise, and for remote auth
+	// and certificate auth, the SDK session is used.
+	transportSession := d.Get("session_auth").(bool) && needCreds && !clients.CommonConfig.RemoteAuth
+	if transportSession {
+		cfg.SkipSessionAuth = true
+	}
+
+	debugLogging := logging.IsDebugOrHigher()
+	// Default SDK client honors proxy environment variables, hence custom
+	// client is only needed with explicit proxy configuration
+	explicitProxy := d.Get("proxy_url").(string) != ""
+	thumbprint := d.Get("server_thumbprint").(string)
+	connectionTimeout := d.Get("connection_timeout").(int)
+	maxIdleConns := d.Get("max_idle_conns").(int)
+	idleConnTimeout := d.Get("idle_conn_timeout").(int)
+	tunedTransport := connectionTimeout != 0 || maxIdleConns != defaultMaxIdleConns || idleConnTimeout != 0
+	if clients.CommonConfig.RequestLimiter != nil || transportSession || debugLogging || explicitProxy || thumbprint != "" || tunedTransport {
+		err := api.InitHttpClient(&cfg)
+		if err != nil {
+			return err
+		}
+		if tr, ok := cfg.HTTPClient.Transport.(*http.Transport); ok {
+			tr.Proxy = clients.CommonConfig.Proxy
+			setTransportConnectionSettings(tr, connectionTimeout, maxIdleConns, idleConnTimeout)
+			if thumbprint != "" {
+				setServerThumbprintVerification(tr.TLSClientConfig, thumbprint)
+			}
+		}
+		if debugLogging {
+			cfg.HTTPClient.Transport = newLoggingTransport(cfg.HTTPClient.Transport)
+		}
+		if clients.CommonConfig.RequestLimiter != nil {
+			cfg.HTTPClient.Transport = newRateLimitedTransport(cfg.HTTPClient.Transport, clients.CommonConfig.RequestLimiter)
+		}
+		if transportSession {
+			loginURL := fmt.Sprintf("https://%s/api/session/create", host)
+			cfg.HTTPClient.Transport = newSessionTransport(cfg.HTTPClient.Transport, loginURL, username, password)
+		}
+	}
+
 	nsxClient, err := api.NewAPIClient(&cfg)
 	if err != nil {
 		return err
@@ -460,6 +689,10 @@ func configureNsxtClient(d *schema.ResourceData, clients *nsxtClients) error {
 
 	clients.NsxtClient = nsxClient
 
+	if len(d.Get("manager_api_version").(string)) > 0 {
+		// Version is set explicitly in providerConfigure
+		return nil
+	}
 	return initNSXVersion(nsxClient)
 }
 
@@ -565,6 +798,10 @@ func getConnectorTLSConfig(d *schema.ResourceData) (*tls.Config, error) {
 		tlsConfig.RootCAs = caCertPool
 	}
 
+	if thumbprint := d.Get("server_thumbprint").(string); len(thumbprint) > 0 {
+		setServerThumbprintVerification(&tlsConfig, thumbprint)
+	}
+
 	return &tlsConfig, nil
 }
 
@@ -634,11 +871,17 @@ func configurePolicyConnectorData(d *schema.ResourceData, clients *nsxtClients)
 	}
 
 	tr := &http.Transport{
-		Proxy:           http.ProxyFromEnvironment,
+		Proxy:           clients.CommonConfig.Proxy,
 		TLSClientConfig: tlsConfig,
 	}
 
-	httpClient := http.Client{Transport: tr}
+	httpClient := http.Client{Transport: newUserAgentTransport(tr, clients.CommonConfig.UserAgent)}
+	if logging.IsDebugOrHigher() {
+		httpClient.Transport = newLoggingTransport(httpClient.Transport)
+	}
+	if clients.CommonConfig.RequestLimiter != nil {
+		httpClient.Transport = newRateLimitedTransport(httpClient.Transport, clients.CommonConfig.RequestLimiter)
+	}
 	clients.PolicyHTTPClient = &httpClient
 	if securityContextNeeded {
 		clients.PolicySecurityContext = securityCtx
@@ -647,7 +890,7 @@ func configurePolicyConnectorData(d *schema.ResourceData, clients *nsxtClients)
 	clients.PolicyEnforcementPoint = policyEnforcementPoint
 	clients.PolicyGlobalManager = policyGlobalManager
 
-	if (len(vmcAccessToken) > 0) || (vmcAuthMode == "Basic") {
+	if ((len(vmcAccessToken) > 0) || (vmcAuthMode == "Basic")) && len(d.Get("manager_api_version").(string)) == 0 {
 		// Special treatment for VMC since MP API is not available there
 		initNSXVersionVMC(*clients)
 	}
@@ -713,6 +956,7 @@ func configureLicenses(d *schema.ResourceData, clients *nsxtClients) error {
 func initCommonConfig(d *schema.ResourceData) commonProviderConfig {
 	remoteAuth := d.Get("remote_auth").(bool)
 	toleratePartialSuccess := d.Get("tolerate_partial_success").(bool)
+	autoRetryOnConflict := d.Get("auto_retry_on_conflict").(bool)
 	maxRetries := d.Get("max_retries").(int)
 	retryMinDelay := d.Get("retry_min_delay").(int)
 	retryMaxDelay := d.Get("retry_max_delay").(int)
@@ -728,6 +972,11 @@ func initCommonConfig(d *schema.ResourceData) commonProviderConfig {
 		retryStatuses = append(retryStatuses, defaultRetryOnStatusCodes...)
 	}
 
+	var limiter *requestLimiter
+	if maxRequestsPerSecond := d.Get("max_requests_per_second").(int); maxRequestsPerSecond > 0 {
+		limiter = newRequestLimiter(maxRequestsPerSecond)
+	}
+
 	return commonProviderConfig{
 		RemoteAuth:             remoteAuth,
 		ToleratePartialSuccess: toleratePartialSuccess,
@@ -735,16 +984,55 @@ func initCommonConfig(d *schema.ResourceData) commonProviderConfig {
 		MinRetryInterval:       retryMinDelay,
 		MaxRetryInterval:       retryMaxDelay,
 		RetryStatusCodes:       retryStatuses,
+		AutoRetryOnConflict:    autoRetryOnConflict,
+		RequestLimiter:         limiter,
+		UserAgent:              getUserAgent(d.Get("user_agent_suffix").(string)),
+	}
+}
+
+func getProxyFunc(d *schema.ResourceData) (func(*http.Request) (*url.URL, error), error) {
+	proxyURL := d.Get("proxy_url").(string)
+	if proxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxy, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid proxy_url %s: %v", proxyURL, err)
+	}
+	if (proxy.Scheme != "http" && proxy.Scheme != "https") || proxy.Host == "" {
+		return nil, fmt.Errorf("Invalid proxy_url %s: expected http or https URL with host", proxyURL)
+	}
+
+	proxyUsername := d.Get("proxy_username").(string)
+	if proxyUsername != "" {
+		proxy.User = url.UserPassword(proxyUsername, d.Get("proxy_password").(string))
 	}
+
+	return http.ProxyURL(proxy), nil
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	if err := validateServerVerificationSettings(d); err != nil {
+		return nil, err
+	}
+
 	commonConfig := initCommonConfig(d)
+	proxy, err := getProxyFunc(d)
+	if err != nil {
+		return nil, err
+	}
+	commonConfig.Proxy = proxy
 	clients := nsxtClients{
 		CommonConfig: commonConfig,
 	}
 
-	err := configureNsxtClient(d, &clients)
+	if apiVersion := d.Get("manager_api_version").(string); len(apiVersion) > 0 {
+		log.Printf("[INFO] Using configured NSX version %s instead of detecting it", apiVersion)
+		nsxVersion = apiVersion
+	}
+
+	err = configureNsxtClient(d, &clients)
 	if err != nil {
 		return nil, err
 	}
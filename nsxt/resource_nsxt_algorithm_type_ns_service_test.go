@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -76,6 +77,21 @@ func TestAccResourceNsxtAlgorithmTypeNsService_importBasic(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtAlgorithmTypeNsService_destinationPortRange(t *testing.T) {
+	serviceName := getAccTestResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXAlgServiceCreateTemplate(serviceName, "FTP", "99", "21-22"),
+				ExpectError: regexp.MustCompile(`to be a single port number`),
+			},
+		},
+	})
+}
+
 func testAccNSXAlgServiceExists(displayName string, resourceName string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
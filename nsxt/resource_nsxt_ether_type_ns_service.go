@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vmware-nsxt/manager"
 )
 
@@ -42,9 +43,10 @@ func resourceNsxtEtherTypeNsService() *schema.Resource {
 				Computed:    true,
 			},
 			"ether_type": {
-				Type:        schema.TypeInt,
-				Description: "Type of the encapsulated protocol",
-				Required:    true,
+				Type:         schema.TypeInt,
+				Description:  "Type of the encapsulated protocol",
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
 			},
 		},
 	}
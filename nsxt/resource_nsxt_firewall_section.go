@@ -4,12 +4,21 @@
 package nsxt
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/common"
 	"github.com/vmware/go-vmware-nsxt/manager"
 )
 
@@ -17,13 +26,20 @@ var firewallRuleIPProtocolValues = []string{"IPV4", "IPV6", "IPV4_IPV6"}
 var firewallRuleActionValues = []string{"ALLOW", "DROP", "REJECT"}
 var firewallRuleDirectionValues = []string{"IN", "OUT", "IN_OUT"}
 var firewallSectionTypeValues = []string{"LAYER2", "LAYER3"}
+var firewallSectionOperationValues = []string{"insert_top", "insert_bottom", "insert_before", "insert_after"}
 
 func resourceNsxtFirewallSection() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNsxtFirewallSectionCreate,
-		Read:   resourceNsxtFirewallSectionRead,
-		Update: resourceNsxtFirewallSectionUpdate,
-		Delete: resourceNsxtFirewallSectionDelete,
+		CreateContext: withManagerDiagnostics(resourceNsxtFirewallSectionCreate),
+		ReadContext:   withManagerDiagnostics(resourceNsxtFirewallSectionRead),
+		UpdateContext: withManagerDiagnostics(resourceNsxtFirewallSectionUpdate),
+		DeleteContext: withManagerDiagnostics(resourceNsxtFirewallSectionDelete),
+		CustomizeDiff: validateFirewallSectionDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -41,7 +57,8 @@ func resourceNsxtFirewallSection() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
-			"tag": getTagsSchema(),
+			"tag":       getTagsSchema(),
+			"tag_merge": getTagMergeSchema(),
 			"is_default": {
 				Type:        schema.TypeBool,
 				Description: "A boolean flag which reflects whether a firewall section is default section or not",
@@ -61,16 +78,169 @@ func resourceNsxtFirewallSection() *schema.Resource {
 			},
 			"applied_to": getResourceReferencesSetSchema(false, false, []string{"LogicalPort", "LogicalSwitch", "NSGroup", "LogicalRouter"}, "List of objects where the rules in this section will be enforced. This will take precedence over rule level appliedTo"),
 			"insert_before": {
-				Type:        schema.TypeString,
-				Description: "Id of section that should come after this one",
+				Type:          schema.TypeString,
+				Description:   "Id of section that should come after this one",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"operation", "anchor_section_id"},
+			},
+			"operation": {
+				Type:          schema.TypeString,
+				Description:   "Position of this section relative to other sections",
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.StringInSlice(firewallSectionOperationValues, false),
+				ConflictsWith: []string{"insert_before"},
+			},
+			"anchor_section_id": {
+				Type:          schema.TypeString,
+				Description:   "Id of the section this section is positioned relative to, for insert_before and insert_after operations",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"insert_before"},
+			},
+			"cascade": {
+				Type:        schema.TypeBool,
+				Description: "Delete the rules of this section together with the section. If false, deleting a non-empty section fails",
 				Optional:    true,
-				ForceNew:    true,
+				Default:     true,
+			},
+			"wait_for_realization": {
+				Type:        schema.TypeBool,
+				Description: "Wait for the section to be realized on NSX after create and update",
+				Optional:    true,
+				Default:     false,
 			},
+			"logged": {
+				Type:        schema.TypeBool,
+				Description: "Default packet logging flag for rules in this section that do not specify logged",
+				Optional:    true,
+				Default:     false,
+			},
+			// Section level enable/disable is not exposed, since the manager
+			// FirewallSection model has no such field. Rules are disabled
+			// individually with the rule level disabled flag.
 			"rule": getRulesSchema(),
 		},
 	}
 }
 
+// validateFirewallSectionDiff fails the plan for stateful LAYER2 sections,
+// which NSX rejects with a generic error upon apply, for relative operation
+// without anchor section, and for rules that reference objects not supported
+// by the section type
+func validateFirewallSectionDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	sectionType := d.Get("section_type").(string)
+	stateful := d.Get("stateful").(bool)
+	if err := validateFirewallSectionStateful(sectionType, stateful); err != nil {
+		return err
+	}
+	// Anchor might be unknown until apply, when it refers to another section
+	if d.NewValueKnown("anchor_section_id") {
+		if err := validateFirewallSectionOperation(d.Get("operation").(string), d.Get("anchor_section_id").(string)); err != nil {
+			return err
+		}
+	}
+	if d.Id() != "" && hasRuleLoggedDrift(d) {
+		// Rule logged flag is not changed in plan when it is inherited from
+		// the section, hence update is triggered via section revision
+		if err := d.SetNewComputed("revision"); err != nil {
+			return err
+		}
+	}
+	rules := getRulesFromList(d.Get("rule").([]interface{}))
+	// Direction is computed, hence only directions set in configuration are
+	// verified
+	for _, rule := range getRulesWithIgnoredDirection(stateful, rules, getDiffRulesWithoutConfig(d, "direction")) {
+		log.Printf("[WARNING] Rule '%s' sets direction %s, which is only considered in stateless sections", rule.DisplayName, rule.Direction)
+	}
+	return validateRulesForSectionType(sectionType, rules)
+}
+
+// suppressInheritedRuleLoggedDiff suppresses diff of rule logged flag that is
+// not specified in configuration and thus inherits the section level flag,
+// as long as the value in state matches the section level flag
+func suppressInheritedRuleLoggedDiff(k, old, new string, d *schema.ResourceData) bool {
+	parts := strings.Split(k, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	index, err := strconv.Atoi(parts[1])
+	if err != nil || !getRulesWithoutConfig(d, "logged")[index] {
+		return false
+	}
+	return old == strconv.FormatBool(d.Get("logged").(bool))
+}
+
+// hasRuleLoggedDrift returns whether any rule that does not specify logged
+// flag in configuration has a value in state other than the section level
+// flag, for example after logged flag was removed from the rule
+func hasRuleLoggedDrift(d *schema.ResourceDiff) bool {
+	sectionLogged := d.Get("logged").(bool)
+	oldRules, _ := d.GetChange("rule")
+	oldRulesList := oldRules.([]interface{})
+	for i := range getDiffRulesWithoutConfig(d, "logged") {
+		if i < len(oldRulesList) && oldRulesList[i].(map[string]interface{})["logged"].(bool) != sectionLogged {
+			return true
+		}
+	}
+	return false
+}
+
+// getDiffRulesWithoutConfig is the plan time counterpart of
+// getRulesWithoutConfig
+func getDiffRulesWithoutConfig(d *schema.ResourceDiff, attrName string) map[int]bool {
+	indexes := make(map[int]bool)
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return indexes
+	}
+	rawRules := rawConfig.GetAttr("rule")
+	if rawRules.IsNull() || !rawRules.IsKnown() {
+		return indexes
+	}
+	for i, rawRule := range rawRules.AsValueSlice() {
+		if rawRule.IsNull() || !rawRule.IsKnown() {
+			continue
+		}
+		if rawRule.GetAttr(attrName).IsNull() {
+			indexes[i] = true
+		}
+	}
+	return indexes
+}
+
+// getRulesWithIgnoredDirection returns rules of a stateful section that set
+// direction other than the default IN_OUT. Rules with given indexes do not
+// specify direction in configuration and are not returned.
+func getRulesWithIgnoredDirection(stateful bool, rules []manager.FirewallRule, withoutDirection map[int]bool) []manager.FirewallRule {
+	var ignored []manager.FirewallRule
+	if !stateful {
+		return ignored
+	}
+	for i, rule := range rules {
+		if withoutDirection[i] || rule.Direction == "" || rule.Direction == "IN_OUT" {
+			continue
+		}
+		ignored = append(ignored, rule)
+	}
+	return ignored
+}
+
+func validateFirewallSectionOperation(operation string, anchorID string) error {
+	if (operation == "insert_before" || operation == "insert_after") && anchorID == "" {
+		return fmt.Errorf("anchor_section_id must be specified for %s operation", operation)
+	}
+	return nil
+}
+
+func validateFirewallSectionStateful(sectionType string, stateful bool) error {
+	if sectionType == "LAYER2" && stateful {
+		return fmt.Errorf("LAYER2 firewall sections can only be stateless, please set stateful to false")
+	}
+	return nil
+}
+
 func getRulesSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeList,
@@ -80,7 +250,7 @@ func getRulesSchema() *schema.Schema {
 			Schema: map[string]*schema.Schema{
 				"id": {
 					Type:        schema.TypeString,
-					Description: "ID of this resource",
+					Description: "ID of this rule, assigned by NSX",
 					Computed:    true,
 				},
 				"revision": getRevisionSchema(),
@@ -111,6 +281,7 @@ func getRulesSchema() *schema.Schema {
 					Type:         schema.TypeString,
 					Description:  "Rule direction in case of stateless firewall rules. This will only be considered if section level parameter is set to stateless. Default to IN_OUT if not specified",
 					Optional:     true,
+					Computed:     true,
 					ValidateFunc: validation.StringInSlice(firewallRuleDirectionValues, false),
 				},
 				"disabled": {
@@ -126,9 +297,10 @@ func getRulesSchema() *schema.Schema {
 					ValidateFunc: validation.StringInSlice(firewallRuleIPProtocolValues, false),
 				},
 				"logged": {
-					Type:        schema.TypeBool,
-					Description: "Flag to enable packet logging. Default is disabled",
-					Optional:    true,
+					Type:             schema.TypeBool,
+					Description:      "Flag to enable packet logging. Defaults to section level logged flag",
+					Optional:         true,
+					DiffSuppressFunc: suppressInheritedRuleLoggedDiff,
 				},
 				"notes": {
 					Type:        schema.TypeString,
@@ -136,9 +308,10 @@ func getRulesSchema() *schema.Schema {
 					Optional:    true,
 				},
 				"rule_tag": {
-					Type:        schema.TypeString,
-					Description: "User level field which will be printed in CLI and packet logs",
-					Optional:    true,
+					Type:         schema.TypeString,
+					Description:  "User level field which will be printed in CLI and packet logs",
+					Optional:     true,
+					ValidateFunc: validateFirewallRuleTag(),
 				},
 				"source": getResourceReferencesSetSchema(false, false, []string{"IPSet", "LogicalPort", "LogicalSwitch", "NSGroup", "MACSet"}, "List of sources. Null will be treated as any"),
 				"sources_excluded": {
@@ -147,6 +320,9 @@ func getRulesSchema() *schema.Schema {
 					Optional:    true,
 				},
 				"service": getResourceReferencesSetSchema(false, false, []string{"NSService", "NSServiceGroup"}, "List of the services. Null will be treated as any"),
+				// Inline service entries, context profiles and rule level tags are not
+				// exposed, since the manager SDK can not express them. See the NOTEs in
+				// docs of this resource for alternatives.
 			},
 		},
 	}
@@ -182,7 +358,7 @@ func setRulesInSchema(d *schema.ResourceData, rules []manager.FirewallRule) erro
 		elem["ip_protocol"] = rule.IpProtocol
 		elem["disabled"] = rule.Disabled
 		elem["revision"] = rule.Revision
-		elem["direction"] = rule.Direction
+		elem["direction"] = getFirewallRuleDirection(rule.Direction)
 		elem["source"] = returnResourceReferencesSet(rule.Sources)
 		elem["destination"] = returnResourceReferencesSet(rule.Destinations)
 		elem["service"] = returnServicesResourceReferences(rule.Services)
@@ -194,6 +370,99 @@ func setRulesInSchema(d *schema.ResourceData, rules []manager.FirewallRule) erro
 	return err
 }
 
+// orderRulesByState aligns rules returned by NSX with the order of rules in
+// current state, so that NSX renumbering rules does not result
+// in a non-empty plan. Rules are matched by id first, and remaining rules by
+// display_name and rule_tag, which covers rules that were assigned a new id.
+// Matched rules keep their state order, while rules unknown to state, such as
+// rules added outside of terraform, are kept right after the matched rule
+// that precedes them on NSX, in order to show as a diff at their position.
+// If relative order of matched rules differs on NSX, rule precedence was
+// changed outside of terraform, and NSX order is returned to show as a diff.
+func orderRulesByState(stateRules []interface{}, rules []manager.FirewallRule) []manager.FirewallRule {
+	if len(stateRules) == 0 || len(rules) == 0 {
+		return rules
+	}
+
+	// statePos holds position in state of the matched NSX rule, or -1
+	statePos := make([]int, len(rules))
+	for i := range statePos {
+		statePos[i] = -1
+	}
+	var unmatched []int
+	for pos, stateRule := range stateRules {
+		data, ok := stateRule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := data["id"].(string)
+		match := -1
+		for i, rule := range rules {
+			if statePos[i] < 0 && id != "" && rule.Id == id {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			unmatched = append(unmatched, pos)
+			continue
+		}
+		statePos[match] = pos
+	}
+	for _, pos := range unmatched {
+		data := stateRules[pos].(map[string]interface{})
+		displayName, _ := data["display_name"].(string)
+		ruleTag, _ := data["rule_tag"].(string)
+		if displayName == "" {
+			continue
+		}
+		for i, rule := range rules {
+			if statePos[i] < 0 && rule.DisplayName == displayName && rule.RuleTag == ruleTag {
+				statePos[i] = pos
+				break
+			}
+		}
+	}
+
+	// Unknown rules are anchored to state position of the preceding
+	// matched rule on NSX, with -1 standing for the top of the section
+	matchedByPos := make(map[int]int)
+	unknownByAnchor := make(map[int][]int)
+	anchor := -1
+	reordered := false
+	for i := range rules {
+		if statePos[i] < 0 {
+			unknownByAnchor[anchor] = append(unknownByAnchor[anchor], i)
+			continue
+		}
+		if statePos[i] < anchor {
+			reordered = true
+		}
+		matchedByPos[statePos[i]] = i
+		anchor = statePos[i]
+	}
+	if reordered {
+		log.Printf("[DEBUG] Firewall rules were reordered on NSX, using NSX order")
+		return rules
+	}
+
+	orderedRules := make([]manager.FirewallRule, 0, len(rules))
+	for _, i := range unknownByAnchor[-1] {
+		orderedRules = append(orderedRules, rules[i])
+	}
+	for pos := range stateRules {
+		i, ok := matchedByPos[pos]
+		if !ok {
+			continue
+		}
+		orderedRules = append(orderedRules, rules[i])
+		for _, j := range unknownByAnchor[pos] {
+			orderedRules = append(orderedRules, rules[j])
+		}
+	}
+	return orderedRules
+}
+
 func getServicesResourceReferences(services []interface{}) []manager.FirewallService {
 	var servicesList []manager.FirewallService
 	for _, srv := range services {
@@ -210,7 +479,61 @@ func getServicesResourceReferences(services []interface{}) []manager.FirewallSer
 }
 
 func getRulesFromSchema(d *schema.ResourceData) []manager.FirewallRule {
-	rules := d.Get("rule").([]interface{})
+	rules := getRulesFromList(d.Get("rule").([]interface{}))
+	sectionLogged := d.Get("logged").(bool)
+	for i := range getRulesWithoutConfig(d, "logged") {
+		if i < len(rules) {
+			rules[i].Logged = sectionLogged
+		}
+	}
+	if !d.Get("stateful").(bool) {
+		// Direction is computed, hence a rule that no longer specifies it
+		// would otherwise keep the previous value
+		withoutDirection := getRulesWithoutConfig(d, "direction")
+		for i := range rules {
+			if withoutDirection[i] || rules[i].Direction == "" {
+				rules[i].Direction = "IN_OUT"
+			}
+		}
+	}
+	return rules
+}
+
+// getFirewallRuleDirection returns the effective direction of the rule, which
+// is IN_OUT unless specified otherwise
+func getFirewallRuleDirection(direction string) string {
+	if direction == "" {
+		return "IN_OUT"
+	}
+	return direction
+}
+
+// getRulesWithoutConfig returns indexes of rules that do not specify given
+// attribute in configuration, and thus inherit the section level or default
+// value. Since such attributes are computed or have zero value when not
+// specified, this can only be told by raw config.
+func getRulesWithoutConfig(d *schema.ResourceData, attrName string) map[int]bool {
+	indexes := make(map[int]bool)
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return indexes
+	}
+	rawRules := rawConfig.GetAttr("rule")
+	if rawRules.IsNull() || !rawRules.IsKnown() {
+		return indexes
+	}
+	for i, rawRule := range rawRules.AsValueSlice() {
+		if rawRule.IsNull() || !rawRule.IsKnown() {
+			continue
+		}
+		if rawRule.GetAttr(attrName).IsNull() {
+			indexes[i] = true
+		}
+	}
+	return indexes
+}
+
+func getRulesFromList(rules []interface{}) []manager.FirewallRule {
 	var ruleList []manager.FirewallRule
 	for _, rule := range rules {
 		data := rule.(map[string]interface{})
@@ -239,12 +562,165 @@ func getRulesFromSchema(d *schema.ResourceData) []manager.FirewallRule {
 	return ruleList
 }
 
-func resourceNsxtFirewallSectionCreate(d *schema.ResourceData, m interface{}) error {
+// validateRulesForSectionType verifies that rule sources and destinations are
+// compatible with the section type: IP sets can not be used in LAYER2 sections,
+// and MAC sets can not be used in LAYER3 sections. This is verified both
+// during plan and before the section is sent to NSX.
+func validateRulesForSectionType(sectionType string, rules []manager.FirewallRule) error {
+	invalidType := "IPSet"
+	if sectionType == "LAYER3" {
+		invalidType = "MACSet"
+	}
+	checkRefs := func(index int, rule manager.FirewallRule, attrName string, refs []common.ResourceReference) error {
+		for _, ref := range refs {
+			if ref.TargetType == invalidType {
+				return fmt.Errorf("Rule %d ('%s') in %s section references %s %s in %s, which is not supported for this section type", index, rule.DisplayName, sectionType, invalidType, ref.TargetId, attrName)
+			}
+		}
+		return nil
+	}
+	for i, rule := range rules {
+		if err := checkRefs(i, rule, "source", rule.Sources); err != nil {
+			return err
+		}
+		if err := checkRefs(i, rule, "destination", rule.Destinations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isFirewallRuleServiceProtocolMismatch returns whether the protocol of an
+// ICMP service can never be matched by a rule with the given ip_protocol
+func isFirewallRuleServiceProtocolMismatch(ipProtocol string, serviceProtocol string) bool {
+	switch serviceProtocol {
+	case "ICMPv4":
+		return ipProtocol == "IPV6"
+	case "ICMPv6":
+		return ipProtocol == "IPV4"
+	}
+	return false
+}
+
+// validateRulesServices verifies that services referenced by rules exist on
+// NSX, and warns about ICMP services that do not match the rule ip_protocol
+func validateRulesServices(ctx context.Context, nsxClient *api.APIClient, rules []manager.FirewallRule) error {
+	// Protocol of ICMP services, or empty string for other existing services
+	serviceProtocols := make(map[string]string)
+	for _, rule := range rules {
+		for _, service := range rule.Services {
+			protocol, ok := serviceProtocols[service.TargetId]
+			if !ok {
+				var resp *http.Response
+				var err error
+				if service.TargetType == "NSServiceGroup" {
+					_, resp, err = nsxClient.GroupingObjectsApi.ReadNSServiceGroup(ctx, service.TargetId)
+				} else {
+					// Any NS service can be read as ICMP service, the
+					// service element is only populated for ICMP types
+					var nsService manager.IcmpTypeNsService
+					nsService, resp, err = nsxClient.GroupingObjectsApi.ReadIcmpTypeNSService(ctx, service.TargetId)
+					if nsService.NsserviceElement.ResourceType == "ICMPTypeNSService" {
+						protocol = nsService.NsserviceElement.Protocol
+					}
+				}
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return fmt.Errorf("Rule '%s' references %s %s, which does not exist", rule.DisplayName, service.TargetType, service.TargetId)
+				}
+				if err != nil {
+					return handleManagerAPIError(fmt.Sprintf("Error during %s %s read", service.TargetType, service.TargetId), err)
+				}
+				serviceProtocols[service.TargetId] = protocol
+			}
+
+			if isFirewallRuleServiceProtocolMismatch(rule.IpProtocol, protocol) {
+				log.Printf("[WARNING] Rule '%s' with ip_protocol %s references %s service %s, which will never be matched", rule.DisplayName, rule.IpProtocol, protocol, service.TargetId)
+			}
+		}
+	}
+	return nil
+}
+
+// getIPAddressFamilies returns whether IP addresses, CIDRs and ranges in the
+// list include IPv4 and IPv6 entries
+func getIPAddressFamilies(addresses []string) (hasIPv4 bool, hasIPv6 bool) {
+	for _, address := range addresses {
+		// For CIDRs and ranges, the first address determines the family
+		address = strings.SplitN(strings.SplitN(address, "/", 2)[0], "-", 2)[0]
+		ip := net.ParseIP(strings.TrimSpace(address))
+		if ip == nil {
+			continue
+		}
+		if ip.To4() != nil {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
+	}
+	return hasIPv4, hasIPv6
+}
+
+// isFirewallRuleIPProtocolMismatch returns whether a rule with the given
+// ip_protocol can never match addresses of the given families
+func isFirewallRuleIPProtocolMismatch(ipProtocol string, hasIPv4 bool, hasIPv6 bool) bool {
+	switch ipProtocol {
+	case "IPV4":
+		return hasIPv6 && !hasIPv4
+	case "IPV6":
+		return hasIPv4 && !hasIPv6
+	}
+	return false
+}
+
+// validateRulesIPProtocol verifies that ip_protocol of rules is compatible
+// with IP sets referenced in sources and destinations. Address family can not
+// be determined for other reference types, hence those are not verified.
+func validateRulesIPProtocol(ctx context.Context, nsxClient *api.APIClient, rules []manager.FirewallRule) error {
+	type ipFamilies struct {
+		hasIPv4 bool
+		hasIPv6 bool
+	}
+	ipSetFamilies := make(map[string]ipFamilies)
+	for i, rule := range rules {
+		if rule.IpProtocol != "IPV4" && rule.IpProtocol != "IPV6" {
+			continue
+		}
+		refs := map[string][]common.ResourceReference{"source": rule.Sources, "destination": rule.Destinations}
+		for _, attrName := range []string{"source", "destination"} {
+			for _, ref := range refs[attrName] {
+				if ref.TargetType != "IPSet" {
+					continue
+				}
+				families, ok := ipSetFamilies[ref.TargetId]
+				if !ok {
+					ipSet, resp, err := nsxClient.GroupingObjectsApi.ReadIPSet(ctx, ref.TargetId)
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return fmt.Errorf("Rule %d ('%s') references IPSet %s in %s, which does not exist", i, rule.DisplayName, ref.TargetId, attrName)
+					}
+					if err != nil {
+						return handleManagerAPIError(fmt.Sprintf("Error during IPSet %s read", ref.TargetId), err)
+					}
+					families.hasIPv4, families.hasIPv6 = getIPAddressFamilies(ipSet.IpAddresses)
+					ipSetFamilies[ref.TargetId] = families
+				}
+				if isFirewallRuleIPProtocolMismatch(rule.IpProtocol, families.hasIPv4, families.hasIPv6) {
+					return fmt.Errorf("Rule %d ('%s') with ip_protocol %s references IPSet %s in %s, which has no addresses of this family", i, rule.DisplayName, rule.IpProtocol, ref.TargetId, attrName)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func resourceNsxtFirewallSectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx, cancel := getOperationContext(ctx, nsxClient, d, schema.TimeoutCreate)
+	defer cancel()
+
 	rules := getRulesFromSchema(d)
 	description := d.Get("description").(string)
 	displayName := d.Get("display_name").(string)
@@ -252,7 +728,16 @@ func resourceNsxtFirewallSectionCreate(d *schema.ResourceData, m interface{}) er
 	appliedTos := getResourceReferencesFromSchemaSet(d, "applied_to")
 	sectionType := d.Get("section_type").(string)
 	stateful := d.Get("stateful").(bool)
-	insertBefore := d.Get("insert_before")
+	if err := validateRulesForSectionType(sectionType, rules); err != nil {
+		return err
+	}
+	if err := validateRulesServices(ctx, nsxClient, rules); err != nil {
+		return err
+	}
+	if err := validateRulesIPProtocol(ctx, nsxClient, rules); err != nil {
+		return err
+	}
+	insertBefore := d.Get("insert_before").(string)
 	firewallSection := manager.FirewallSectionRuleList{
 		FirewallSection: manager.FirewallSection{
 			Description: description,
@@ -265,48 +750,171 @@ func resourceNsxtFirewallSectionCreate(d *schema.ResourceData, m interface{}) er
 		Rules: rules,
 	}
 
-	localVarOptionals := make(map[string]interface{})
+	operation := d.Get("operation").(string)
+	anchorID := d.Get("anchor_section_id").(string)
 	if insertBefore != "" {
-		localVarOptionals["operation"] = "insert_before"
-		localVarOptionals["id"] = insertBefore
+		operation = "insert_before"
+		anchorID = insertBefore
+	}
+
+	localVarOptionals := make(map[string]interface{})
+	if operation != "" {
+		localVarOptionals["operation"] = operation
+		if anchorID != "" {
+			localVarOptionals["id"] = anchorID
+		}
 	}
 
 	var resp *http.Response
 	var err error
+	createStart := time.Now()
 	if len(rules) == 0 {
 		section := *firewallSection.GetFirewallSection()
-		section, resp, err = nsxClient.ServicesApi.AddSection(nsxClient.Context, section, localVarOptionals)
+		section, resp, err = nsxClient.ServicesApi.AddSection(ctx, section, localVarOptionals)
 		d.SetId(section.Id)
 	} else {
-		firewallSection, resp, err = nsxClient.ServicesApi.AddSectionWithRulesCreateWithRules(nsxClient.Context, firewallSection, localVarOptionals)
+		firewallSection, resp, err = nsxClient.ServicesApi.AddSectionWithRulesCreateWithRules(ctx, firewallSection, localVarOptionals)
 		d.SetId(firewallSection.Id)
 	}
 
 	if err != nil {
-		return fmt.Errorf("Error during FirewallSection create with rules: %v", err)
+		createErr := handleManagerAPIError("Error during FirewallSection create with rules", err)
+		if d.Id() != "" {
+			// Section was created partially, roll it back together with its rules
+			return resourceNsxtFirewallSectionCreateRollback(ctx, d, nsxClient, createErr)
+		}
+		if isAmbiguousManagerAPIError(resp, err) {
+			// The request might have failed after the section was created, for
+			// example on timeout. Keep such section in state, so that it is
+			// marked as tainted and replaced on next apply rather than orphaned.
+			sections, listErr := listFirewallSections(nsxClient)
+			if listErr == nil {
+				if id := findFirewallSectionCreatedSince(sections, displayName, createStart); id != "" {
+					log.Printf("[WARNING] FirewallSection %s was created despite failure, keeping it in state to be replaced", id)
+					d.SetId(id)
+				}
+			}
+		}
+		return createErr
 	}
 
 	if resp.StatusCode != http.StatusCreated {
 		return fmt.Errorf("Unexpected status returned during FirewallSection create with rules: %v", resp.StatusCode)
 	}
 
-	return resourceNsxtFirewallSectionRead(d, m)
+	return resourceNsxtFirewallSectionWaitAndRead(ctx, d, m, schema.TimeoutCreate)
+}
+
+// resourceNsxtFirewallSectionCreateRollback deletes section that was created
+// by failed request. If the section can not be deleted, it is kept in state,
+// so that it is marked as tainted and replaced on next apply.
+func resourceNsxtFirewallSectionCreateRollback(ctx context.Context, d *schema.ResourceData, nsxClient *api.APIClient, createErr error) error {
+	id := d.Id()
+	localVarOptionals := make(map[string]interface{})
+	localVarOptionals["cascade"] = true
+	resp, err := nsxClient.ServicesApi.DeleteSection(ctx, id, localVarOptionals)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return handleManagerAPIError(fmt.Sprintf("%v\nError during FirewallSection %s rollback", createErr, id), err)
+	}
+	d.SetId("")
+	return createErr
+}
+
+// firewallSectionCreateTimeSkew allows for clock difference between
+// terraform and NSX when looking for sections created by failed request
+const firewallSectionCreateTimeSkew = time.Minute
+
+// findFirewallSectionCreatedSince returns id of the single section with given
+// display name that was created after the given time, or empty string if no
+// such section, or more than one, exists
+func findFirewallSectionCreatedSince(sections []manager.FirewallSection, displayName string, since time.Time) string {
+	if displayName == "" {
+		return ""
+	}
+	sinceMillis := since.Add(-firewallSectionCreateTimeSkew).UnixNano() / int64(time.Millisecond)
+	id := ""
+	for _, section := range sections {
+		if section.DisplayName != displayName || section.CreateTime < sinceMillis {
+			continue
+		}
+		if id != "" {
+			return ""
+		}
+		id = section.Id
+	}
+	return id
+}
+
+// resourceNsxtFirewallSectionWaitAndRead waits for the section to be realized
+// if requested, and reads it
+func resourceNsxtFirewallSectionWaitAndRead(ctx context.Context, d *schema.ResourceData, m interface{}, operation string) error {
+	if d.Get("wait_for_realization").(bool) {
+		nsxClient := m.(nsxtClients).NsxtClient
+		toleratePartialSuccess := getCommonProviderConfig(m.(nsxtClients)).ToleratePartialSuccess
+		ctx, cancel := getOperationContext(ctx, nsxClient, d, operation)
+		defer cancel()
+		if err := resourceNsxtFirewallSectionWaitForRealization(ctx, nsxClient, d.Id(), d.Timeout(operation), toleratePartialSuccess); err != nil {
+			return err
+		}
+	}
+	return resourceNsxtFirewallSectionRead(ctx, d, m)
+}
+
+func resourceNsxtFirewallSectionWaitForRealization(ctx context.Context, nsxClient *api.APIClient, id string, timeout time.Duration, toleratePartialSuccess bool) error {
+	pendingStates := []string{"pending", "in_progress"}
+	targetStates := []string{"success"}
+	if toleratePartialSuccess {
+		targetStates = append(targetStates, "partial_success")
+	} else {
+		pendingStates = append(pendingStates, "partial_success")
+	}
+	stateConf := &resource.StateChangeConf{
+		Pending: pendingStates,
+		Target:  targetStates,
+		Refresh: func() (interface{}, string, error) {
+			state, resp, err := nsxClient.ServicesApi.GetSectionState(ctx, id, nil)
+			if err != nil {
+				return nil, "", handleManagerAPIError(fmt.Sprintf("Error while querying FirewallSection %s realization state", id), err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				return nil, "", fmt.Errorf("Unexpected return status %d", resp.StatusCode)
+			}
+
+			if state.FailureCode != 0 {
+				return nil, "", fmt.Errorf("Error in FirewallSection %s realization: %s", id, state.FailureMessage)
+			}
+
+			log.Printf("[DEBUG] FirewallSection %s realization state: %s", id, state.State)
+			return state, state.State, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 1 * time.Second,
+		Delay:      1 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to realize FirewallSection %s: %v", id, err)
+	}
+	return nil
 }
 
-func resourceNsxtFirewallSectionRead(d *schema.ResourceData, m interface{}) error {
+func resourceNsxtFirewallSectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx = getManagerContext(ctx, nsxClient)
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining logical object id")
 	}
 
-	firewallSection, resp, err := nsxClient.ServicesApi.GetSectionWithRulesListWithRules(nsxClient.Context, id)
+	firewallSection, resp, err := nsxClient.ServicesApi.GetSectionWithRulesListWithRules(ctx, id)
 	if err != nil {
-		return fmt.Errorf("Error during FirewallSection %s read: %v", id, err)
+		return handleManagerAPIError(fmt.Sprintf("Error during FirewallSection %s read", id), err)
 	}
 	if resp.StatusCode == http.StatusNotFound {
 		log.Printf("[DEBUG] FirewallSection %s not found", id)
@@ -320,23 +928,41 @@ func resourceNsxtFirewallSectionRead(d *schema.ResourceData, m interface{}) erro
 	d.Set("is_default", firewallSection.IsDefault)
 	d.Set("section_type", firewallSection.SectionType)
 	d.Set("stateful", firewallSection.Stateful)
-	setTagsInSchema(d, firewallSection.Tags)
-	err = setRulesInSchema(d, firewallSection.Rules)
+	if _, ok := d.GetOkExists("cascade"); !ok {
+		// Not kept on NSX side, populate the default on import
+		d.Set("cascade", true)
+	}
+	if _, ok := d.GetOkExists("wait_for_realization"); !ok {
+		d.Set("wait_for_realization", false)
+	}
+	if _, ok := d.GetOkExists("logged"); !ok {
+		d.Set("logged", false)
+	}
+	setMergedTagsInSchema(d, firewallSection.Tags)
+	rules := orderRulesByState(d.Get("rule").([]interface{}), firewallSection.Rules)
+	err = setRulesInSchema(d, rules)
 	if err != nil {
 		return fmt.Errorf("Error during FirewallSection rules set in schema: %v", err)
 	}
 
-	// Getting the applied tos will require another api call (for NSX 2.1 or less)
-	firewallSection2, resp, err := nsxClient.ServicesApi.GetSection(nsxClient.Context, id)
-	if resp != nil && resp.StatusCode == http.StatusNotFound {
-		log.Printf("[DEBUG] FirewallSection %s not found", id)
-		d.SetId("")
-		return nil
-	}
-	if err != nil {
-		return fmt.Errorf("Error during FirewallSection %s read: %v", id, err)
+	appliedTos := firewallSection.AppliedTos
+	if len(appliedTos) == 0 && nsxVersionLower("2.2.0") {
+		// Getting the applied tos will require another api call (for NSX 2.1 or less).
+		// The call is skipped if applied tos are already included in the response,
+		// which also covers the case where NSX version could not be detected.
+		firewallSection2, resp, err := nsxClient.ServicesApi.GetSection(ctx, id)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			log.Printf("[DEBUG] FirewallSection %s not found", id)
+			d.SetId("")
+			return nil
+		}
+		if err != nil {
+			return handleManagerAPIError(fmt.Sprintf("Error during FirewallSection %s read", id), err)
+		}
+		appliedTos = firewallSection2.AppliedTos
 	}
-	err = setResourceReferencesInSchema(d, firewallSection2.AppliedTos, "applied_to")
+	// Empty applied_tos is valid, and results in empty applied_to in state
+	err = setResourceReferencesInSchema(d, appliedTos, "applied_to")
 	if err != nil {
 		return fmt.Errorf("Error during FirewallSection AppliedTos set in schema: %v", err)
 	}
@@ -344,12 +970,15 @@ func resourceNsxtFirewallSectionRead(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceNsxtFirewallSectionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceNsxtFirewallSectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx, cancel := getOperationContext(ctx, nsxClient, d, schema.TimeoutUpdate)
+	defer cancel()
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining logical object id")
@@ -359,10 +988,27 @@ func resourceNsxtFirewallSectionUpdate(d *schema.ResourceData, m interface{}) er
 	revision := int64(d.Get("revision").(int))
 	description := d.Get("description").(string)
 	displayName := d.Get("display_name").(string)
-	tags := getTagsFromSchema(d)
+	var tagsResp *http.Response
+	tags, err := getMergedTagsFromSchema(d, func() ([]common.Tag, error) {
+		currSection, resp, err := nsxClient.ServicesApi.GetSection(ctx, id)
+		tagsResp = resp
+		return currSection.Tags, err
+	})
+	if err != nil {
+		return resourceNsxtFirewallSectionUpdateError(ctx, d, nsxClient, fmt.Sprintf("Error during FirewallSection %s update: cannot read the section tags", id), tagsResp, err)
+	}
 	appliedTos := getResourceReferencesFromSchemaSet(d, "applied_to")
 	sectionType := d.Get("section_type").(string)
 	stateful := d.Get("stateful").(bool)
+	if err := validateRulesForSectionType(sectionType, rules); err != nil {
+		return err
+	}
+	if err := validateRulesServices(ctx, nsxClient, rules); err != nil {
+		return err
+	}
+	if err := validateRulesIPProtocol(ctx, nsxClient, rules); err != nil {
+		return err
+	}
 	firewallSection := manager.FirewallSectionRuleList{
 		FirewallSection: manager.FirewallSection{
 			Revision:    revision,
@@ -377,59 +1023,292 @@ func resourceNsxtFirewallSectionUpdate(d *schema.ResourceData, m interface{}) er
 		Rules: rules,
 	}
 
-	var resp *http.Response
-	var err error
-	if len(rules) == 0 || nsxVersionLower("2.2.0") {
-		// Due to an NSX bug, the empty update should also be called to update ToS & tags fields
-		section := *firewallSection.GetFirewallSection()
-		// Update the section ignoring the rules
-		_, resp, err = nsxClient.ServicesApi.UpdateSection(nsxClient.Context, id, section)
-
-		if len(rules) == 0 {
-			// Read the section, and delete all current rules from it
-			currSection, resp2, err2 := nsxClient.ServicesApi.GetSectionWithRulesListWithRules(nsxClient.Context, id)
-			if resp2.StatusCode == http.StatusNotFound {
-				return fmt.Errorf("FirewallSection %s not found during update empty action", id)
+	if len(rules) == 0 {
+		message, resp, err := resourceNsxtFirewallSectionUpdateEmpty(ctx, nsxClient, id, firewallSection)
+		if err != nil {
+			return resourceNsxtFirewallSectionUpdateError(ctx, d, nsxClient, message, resp, err)
+		}
+		return resourceNsxtFirewallSectionWaitAndRead(ctx, d, m, schema.TimeoutUpdate)
+	}
+
+	retryOnConflict := m.(nsxtClients).CommonConfig.AutoRetryOnConflict
+	oldRulesList, _ := d.GetChange("rule")
+	oldRules := getRulesFromList(oldRulesList.([]interface{}))
+	// Rules keep their ids even when rules are inserted or reordered
+	firewallSection.Rules, _ = matchFirewallRulesByID(oldRules, rules)
+	if !d.HasChanges("display_name", "description", "tag", "tag_merge", "applied_to", "section_type", "logged") {
+		// Only a single rule changed, modify it rather than replacing the whole
+		// section. Multiple rule changes are applied with a single section
+		// update, so that failure does not leave the section half updated.
+		toUpdate, toAdd, toDelete, ok := diffFirewallRules(oldRules, rules)
+		if ok && len(toUpdate)+len(toAdd)+len(toDelete) <= 1 {
+			message, resp, err := resourceNsxtFirewallSectionUpdateRules(ctx, nsxClient, id, toUpdate, toAdd, toDelete, retryOnConflict)
+			if err != nil {
+				return resourceNsxtFirewallSectionUpdateError(ctx, d, nsxClient, message, resp, err)
 			}
-			if err2 != nil {
-				return fmt.Errorf("Error during FirewallSection %s update empty: cannot read the section: %v", id, err2)
+			return resourceNsxtFirewallSectionWaitAndRead(ctx, d, m, schema.TimeoutUpdate)
+		}
+	}
+
+	refreshRevision := func() error {
+		currSection, _, err := nsxClient.ServicesApi.GetSection(ctx, id)
+		firewallSection.Revision = currSection.Revision
+		return err
+	}
+
+	if nsxVersionLower("2.2.0") {
+		// Due to an NSX bug, the empty update should also be called to update ToS & tags fields
+		var section manager.FirewallSection
+		resp, err := retryUponConflict(retryOnConflict,
+			func() (*http.Response, error) {
+				updatedSection, resp, err := nsxClient.ServicesApi.UpdateSection(ctx, id, *firewallSection.GetFirewallSection())
+				section = updatedSection
+				return resp, err
+			}, refreshRevision)
+		if err != nil || (resp != nil && resp.StatusCode == http.StatusNotFound) {
+			return resourceNsxtFirewallSectionUpdateError(ctx, d, nsxClient, fmt.Sprintf("Error during FirewallSection %s update", id), resp, err)
+		}
+		// Section update bumps the revision
+		firewallSection.Revision = section.Revision
+	}
+
+	// If we have rules - update the section with the rules
+	resp, err := retryUponConflict(retryOnConflict,
+		func() (*http.Response, error) {
+			_, resp, err := nsxClient.ServicesApi.UpdateSectionWithRulesUpdateWithRules(ctx, id, firewallSection)
+			return resp, err
+		}, refreshRevision)
+	if err != nil || (resp != nil && resp.StatusCode == http.StatusNotFound) {
+		return resourceNsxtFirewallSectionUpdateError(ctx, d, nsxClient, fmt.Sprintf("Error during FirewallSection %s update", id), resp, err)
+	}
+
+	return resourceNsxtFirewallSectionWaitAndRead(ctx, d, m, schema.TimeoutUpdate)
+}
+
+// resourceNsxtFirewallSectionUpdateError handles failed section update, given
+// response and error of the failed call. If the section was deleted outside of
+// terraform, this is reported explicitly, and the section is removed from state
+// on next refresh and recreated, similar to read. Since not found response may
+// also refer to a rule, for example a rule deleted outside of terraform, the
+// section is read to tell those apart. Other failures are reported with the
+// given message.
+func resourceNsxtFirewallSectionUpdateError(ctx context.Context, d *schema.ResourceData, nsxClient *api.APIClient, message string, resp *http.Response, err error) error {
+	id := d.Id()
+	if resp == nil || resp.StatusCode == http.StatusNotFound {
+		_, sectionResp, _ := nsxClient.ServicesApi.GetSection(ctx, id)
+		if sectionResp != nil && sectionResp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("FirewallSection %s was deleted outside of terraform and will be recreated on next apply", id)
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("not found")
+	}
+	return handleManagerAPIError(message, err)
+}
+
+// matchFirewallRulesByID assigns ids and revisions of rules in state to
+// planned rules. Since rule id is computed, planned rule carries the id of the
+// rule at same position in state, which belongs to another rule once rules are
+// inserted, removed or reordered. Hence planned rule keeps its id only if
+// the state rule with this id has same display_name and rule_tag, otherwise
+// it is matched by display_name and rule_tag among remaining state rules, or
+// is considered a new rule. Returned indexes are positions of matched state
+// rules, or -1 for new rules.
+func matchFirewallRulesByID(oldRules []manager.FirewallRule, newRules []manager.FirewallRule) ([]manager.FirewallRule, []int) {
+	matchedRules := make([]manager.FirewallRule, len(newRules))
+	indexes := make([]int, len(newRules))
+	used := make([]bool, len(oldRules))
+	sameRule := func(oldRule manager.FirewallRule, newRule manager.FirewallRule) bool {
+		return oldRule.DisplayName == newRule.DisplayName && oldRule.RuleTag == newRule.RuleTag
+	}
+	for i, newRule := range newRules {
+		indexes[i] = -1
+		for j, oldRule := range oldRules {
+			if !used[j] && newRule.Id != "" && oldRule.Id == newRule.Id && sameRule(oldRule, newRule) {
+				indexes[i] = j
+				used[j] = true
+				break
 			}
-			for _, rule := range currSection.Rules {
-				_, err3 := nsxClient.ServicesApi.DeleteRule(nsxClient.Context, id, rule.Id)
-				if err3 != nil {
-					return fmt.Errorf("Error during FirewallSection %s update: failed to delete rule %s due to %v", id, rule.Id, err3)
-				}
+		}
+	}
+	for i, newRule := range newRules {
+		if indexes[i] >= 0 {
+			continue
+		}
+		for j, oldRule := range oldRules {
+			if !used[j] && oldRule.Id != "" && sameRule(oldRule, newRule) {
+				indexes[i] = j
+				used[j] = true
+				break
 			}
 		}
 	}
-	if len(rules) > 0 {
-		// If we have rules - update the section with the rules
-		_, resp, err = nsxClient.ServicesApi.UpdateSectionWithRulesUpdateWithRules(nsxClient.Context, id, firewallSection)
+
+	for i, newRule := range newRules {
+		if indexes[i] >= 0 {
+			newRule.Id = oldRules[indexes[i]].Id
+			newRule.Revision = oldRules[indexes[i]].Revision
+		} else {
+			newRule.Id = ""
+			newRule.Revision = 0
+		}
+		matchedRules[i] = newRule
 	}
+	return matchedRules, indexes
+}
 
-	if err != nil || resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("Error during FirewallSection %s update: %v", id, err)
+// diffFirewallRules compares rules in state with planned rules matched by
+// id, and returns rules that need to be updated, appended and deleted.
+// Unchanged rules keep their id and revision. If rules were reordered or
+// a rule was inserted before existing ones, or if rules in state are missing
+// ids, ok is false and full section update is needed.
+func diffFirewallRules(oldRules []manager.FirewallRule, newRules []manager.FirewallRule) (toUpdate []manager.FirewallRule, toAdd []manager.FirewallRule, toDelete []string, ok bool) {
+	for _, oldRule := range oldRules {
+		if oldRule.Id == "" {
+			return nil, nil, nil, false
+		}
 	}
 
-	return resourceNsxtFirewallSectionRead(d, m)
+	matchedRules, indexes := matchFirewallRulesByID(oldRules, newRules)
+	used := make([]bool, len(oldRules))
+	lastIndex := -1
+	for i, newRule := range matchedRules {
+		if indexes[i] < 0 {
+			toAdd = append(toAdd, newRule)
+			continue
+		}
+		if indexes[i] < lastIndex || len(toAdd) > 0 {
+			// Order changed, or new rule is not last
+			return nil, nil, nil, false
+		}
+		lastIndex = indexes[i]
+		used[indexes[i]] = true
+		if !reflect.DeepEqual(newRule, oldRules[indexes[i]]) {
+			toUpdate = append(toUpdate, newRule)
+		}
+	}
+	for j, oldRule := range oldRules {
+		if !used[j] {
+			toDelete = append(toDelete, oldRule.Id)
+		}
+	}
+	return toUpdate, toAdd, toDelete, true
+}
+
+// resourceNsxtFirewallSectionUpdateRules deletes, updates and adds the given
+// rules. Upon failure, a message describing the failed call is returned along
+// with its response and error.
+func resourceNsxtFirewallSectionUpdateRules(ctx context.Context, nsxClient *api.APIClient, id string, toUpdate []manager.FirewallRule, toAdd []manager.FirewallRule, toDelete []string, retryOnConflict bool) (string, *http.Response, error) {
+	for _, ruleID := range toDelete {
+		log.Printf("[DEBUG] Deleting rule %s of FirewallSection %s", ruleID, id)
+		resp, err := nsxClient.ServicesApi.DeleteRule(ctx, id, ruleID)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			log.Printf("[DEBUG] Rule %s of FirewallSection %s was already deleted", ruleID, id)
+			continue
+		}
+		if err != nil {
+			return fmt.Sprintf("Error during FirewallSection %s rule %s delete", id, ruleID), resp, err
+		}
+	}
+
+	for _, rule := range toUpdate {
+		log.Printf("[DEBUG] Updating rule %s of FirewallSection %s", rule.Id, id)
+		refreshRevision := func() error {
+			currRule, _, err := nsxClient.ServicesApi.GetRule(ctx, id, rule.Id)
+			rule.Revision = currRule.Revision
+			return err
+		}
+		resp, err := retryUponConflict(retryOnConflict,
+			func() (*http.Response, error) {
+				_, resp, err := nsxClient.ServicesApi.UpdateRule(ctx, id, rule.Id, rule)
+				return resp, err
+			}, refreshRevision)
+		if err != nil || resp.StatusCode == http.StatusNotFound {
+			return fmt.Sprintf("Error during FirewallSection %s rule %s update", id, rule.Id), resp, err
+		}
+	}
+
+	for _, rule := range toAdd {
+		log.Printf("[DEBUG] Adding rule %s to FirewallSection %s", rule.DisplayName, id)
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["operation"] = "insert_bottom"
+		_, resp, err := nsxClient.ServicesApi.AddRuleInSection(ctx, id, rule, localVarOptionals)
+		if err != nil {
+			return fmt.Sprintf("Error during FirewallSection %s rule add", id), resp, err
+		}
+	}
+
+	return "", nil, nil
 }
 
-func resourceNsxtFirewallSectionDelete(d *schema.ResourceData, m interface{}) error {
+// resourceNsxtFirewallSectionUpdateEmpty updates the section and removes all
+// its rules. Upon failure, a message describing the failed call is returned
+// along with its response and error.
+func resourceNsxtFirewallSectionUpdateEmpty(ctx context.Context, nsxClient *api.APIClient, id string, firewallSection manager.FirewallSectionRuleList) (string, *http.Response, error) {
+	message := fmt.Sprintf("Error during FirewallSection %s update empty", id)
+	if nsxVersionHigherOrEqual("3.0.0") {
+		// Section is updated and cleared of rules in a single call, with section
+		// revision guarding against concurrent modifications
+		firewallSection.Rules = make([]manager.FirewallRule, 0)
+		_, resp, err := nsxClient.ServicesApi.UpdateSectionWithRulesUpdateWithRules(ctx, id, firewallSection)
+		if err != nil || (resp != nil && resp.StatusCode == http.StatusNotFound) {
+			return message, resp, err
+		}
+		return "", nil, nil
+	}
+
+	// Update the section ignoring the rules
+	section, resp, err := nsxClient.ServicesApi.UpdateSection(ctx, id, *firewallSection.GetFirewallSection())
+	if err != nil || (resp != nil && resp.StatusCode == http.StatusNotFound) {
+		return message, resp, err
+	}
+
+	// Read the section, and delete all current rules from it
+	currSection, resp, err := nsxClient.ServicesApi.GetSectionWithRulesListWithRules(ctx, id)
+	if err != nil || (resp != nil && resp.StatusCode == http.StatusNotFound) {
+		return fmt.Sprintf("%s: cannot read the section", message), resp, err
+	}
+	if currSection.Revision != section.Revision {
+		return message, resp, fmt.Errorf("section was modified concurrently (revision %d, expected %d)", currSection.Revision, section.Revision)
+	}
+
+	var deleteErrors []string
+	for _, rule := range currSection.Rules {
+		resp, err := nsxClient.ServicesApi.DeleteRule(ctx, id, rule.Id)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			log.Printf("[DEBUG] Rule %s of FirewallSection %s was already deleted", rule.Id, id)
+			continue
+		}
+		if err != nil {
+			deleteErrors = append(deleteErrors, fmt.Sprintf("rule %s: %v", rule.Id, err))
+		}
+	}
+	if len(deleteErrors) > 0 {
+		return message, resp, fmt.Errorf("failed to delete rules: %s", strings.Join(deleteErrors, "; "))
+	}
+
+	return "", nil, nil
+}
+
+func resourceNsxtFirewallSectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx, cancel := getOperationContext(ctx, nsxClient, d, schema.TimeoutDelete)
+	defer cancel()
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining logical object id to delete")
 	}
 
 	localVarOptionals := make(map[string]interface{})
-	localVarOptionals["cascade"] = true
-	resp, err := nsxClient.ServicesApi.DeleteSection(nsxClient.Context, id, localVarOptionals)
+	localVarOptionals["cascade"] = d.Get("cascade").(bool)
+	resp, err := nsxClient.ServicesApi.DeleteSection(ctx, id, localVarOptionals)
 	if err != nil {
-		return fmt.Errorf("Error during FirewallSection %s delete: %v", id, err)
+		return handleManagerAPIError(fmt.Sprintf("Error during FirewallSection %s delete", id), err)
 	}
 
 	if resp.StatusCode == http.StatusNotFound {
@@ -0,0 +1,230 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func resourceNsxtFirewallSectionOrdering() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNsxtFirewallSectionOrderingCreate,
+		Read:   resourceNsxtFirewallSectionOrderingRead,
+		Update: resourceNsxtFirewallSectionOrderingUpdate,
+		Delete: resourceNsxtFirewallSectionOrderingDelete,
+
+		Schema: map[string]*schema.Schema{
+			"section_ids": {
+				Type:        schema.TypeList,
+				Description: "Ids of firewall sections in the order they should be enforced",
+				Required:    true,
+				MinItems:    2,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+// firewallSectionMove describes a section to be positioned right after anchor
+type firewallSectionMove struct {
+	SectionID string
+	AnchorID  string
+}
+
+// getFirewallSectionMoves computes the moves needed for sections in
+// desiredOrder to appear in this relative order in currentOrder. Sections
+// already in order are not moved, and sections out of order are moved
+// right after their predecessor in desiredOrder.
+func getFirewallSectionMoves(currentOrder []string, desiredOrder []string) []firewallSectionMove {
+	order := append([]string{}, currentOrder...)
+	indexOf := func(id string) int {
+		for i, sectionID := range order {
+			if sectionID == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var moves []firewallSectionMove
+	for i := 1; i < len(desiredOrder); i++ {
+		prevIndex := indexOf(desiredOrder[i-1])
+		index := indexOf(desiredOrder[i])
+		if index > prevIndex {
+			continue
+		}
+		// Move the section right after its predecessor
+		order = append(order[:index], order[index+1:]...)
+		prevIndex = indexOf(desiredOrder[i-1])
+		order = append(order[:prevIndex+1], append([]string{desiredOrder[i]}, order[prevIndex+1:]...)...)
+		moves = append(moves, firewallSectionMove{SectionID: desiredOrder[i], AnchorID: desiredOrder[i-1]})
+	}
+	return moves
+}
+
+// listFirewallSections returns all firewall sections in the order they are
+// enforced on NSX
+func listFirewallSections(nsxClient *api.APIClient) ([]manager.FirewallSection, error) {
+	var sections []manager.FirewallSection
+	lister := func(info *paginationInfo) error {
+		objList, _, err := nsxClient.ServicesApi.ListSections(nsxClient.Context, info.LocalVarOptionals)
+		if err != nil {
+			return handleManagerAPIError("Error while reading firewall sections", err)
+		}
+
+		info.PageCount = int64(len(objList.Results))
+		info.TotalCount = objList.ResultCount
+		info.Cursor = objList.Cursor
+
+		sections = append(sections, objList.Results...)
+		return nil
+	}
+
+	_, err := handlePagination(lister)
+	return sections, err
+}
+
+// getFirewallSectionsCurrentOrder returns ids of the given sections in their
+// current order on NSX. Sections that do not exist on NSX are omitted.
+func getFirewallSectionsCurrentOrder(sections []manager.FirewallSection, sectionIDs []string) []string {
+	managed := make(map[string]bool)
+	for _, id := range sectionIDs {
+		managed[id] = true
+	}
+	var order []string
+	for _, section := range sections {
+		if managed[section.Id] {
+			order = append(order, section.Id)
+		}
+	}
+	return order
+}
+
+// validateFirewallSectionsForOrdering verifies that listed sections can be
+// ordered relative to each other before any section is moved: sections must
+// be of same section type, and default sections can not be moved
+func validateFirewallSectionsForOrdering(sections []manager.FirewallSection, sectionIDs []string) error {
+	sectionType := ""
+	for _, section := range sections {
+		for _, id := range sectionIDs {
+			if section.Id != id {
+				continue
+			}
+			if section.IsDefault {
+				return fmt.Errorf("Firewall section %s is a default section, which can not be moved, and should not be listed in section_ids", id)
+			}
+			if sectionType != "" && section.SectionType != sectionType {
+				return fmt.Errorf("Firewall sections in section_ids must be of same section type, section %s is %s while previous sections are %s", id, section.SectionType, sectionType)
+			}
+			sectionType = section.SectionType
+		}
+	}
+	return nil
+}
+
+func resourceNsxtFirewallSectionOrderingApply(d *schema.ResourceData, nsxClient *api.APIClient) error {
+	sectionIDs := interface2StringList(d.Get("section_ids").([]interface{}))
+	sections, err := listFirewallSections(nsxClient)
+	if err != nil {
+		return err
+	}
+
+	if err := validateFirewallSectionsForOrdering(sections, sectionIDs); err != nil {
+		return err
+	}
+	var allIDs []string
+	for _, section := range sections {
+		allIDs = append(allIDs, section.Id)
+	}
+
+	currentOrder := getFirewallSectionsCurrentOrder(sections, sectionIDs)
+	if len(currentOrder) != len(sectionIDs) {
+		return fmt.Errorf("Some of the firewall sections %v were not found, or are listed more than once", sectionIDs)
+	}
+
+	for _, move := range getFirewallSectionMoves(allIDs, sectionIDs) {
+		log.Printf("[INFO] Moving FirewallSection %s after section %s", move.SectionID, move.AnchorID)
+		section, _, err := nsxClient.ServicesApi.GetSection(nsxClient.Context, move.SectionID)
+		if err != nil {
+			return handleManagerAPIError(fmt.Sprintf("Error during FirewallSection %s read", move.SectionID), err)
+		}
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["operation"] = "insert_after"
+		localVarOptionals["id"] = move.AnchorID
+		_, _, err = nsxClient.ServicesApi.ReviseSectionRevise(nsxClient.Context, move.SectionID, section, localVarOptionals)
+		if err != nil {
+			return handleManagerAPIError(fmt.Sprintf("Error while moving FirewallSection %s after section %s", move.SectionID, move.AnchorID), err)
+		}
+	}
+
+	return nil
+}
+
+func resourceNsxtFirewallSectionOrderingCreate(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return resourceNotSupportedError()
+	}
+
+	err := resourceNsxtFirewallSectionOrderingApply(d, nsxClient)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(newUUID())
+	return resourceNsxtFirewallSectionOrderingRead(d, m)
+}
+
+func resourceNsxtFirewallSectionOrderingRead(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return resourceNotSupportedError()
+	}
+
+	sectionIDs := interface2StringList(d.Get("section_ids").([]interface{}))
+	sections, err := listFirewallSections(nsxClient)
+	if err != nil {
+		return err
+	}
+
+	// Ordering in state reflects the order on NSX, so that any deviation
+	// from configured order shows as a diff
+	currentOrder := getFirewallSectionsCurrentOrder(sections, sectionIDs)
+	if len(currentOrder) == 0 {
+		log.Printf("[DEBUG] None of FirewallSections %v were found", sectionIDs)
+		d.SetId("")
+		return nil
+	}
+	d.Set("section_ids", currentOrder)
+
+	return nil
+}
+
+func resourceNsxtFirewallSectionOrderingUpdate(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return resourceNotSupportedError()
+	}
+
+	err := resourceNsxtFirewallSectionOrderingApply(d, nsxClient)
+	if err != nil {
+		return err
+	}
+
+	return resourceNsxtFirewallSectionOrderingRead(d, m)
+}
+
+func resourceNsxtFirewallSectionOrderingDelete(d *schema.ResourceData, m interface{}) error {
+	// Sections are left in their current order
+	return nil
+}
@@ -0,0 +1,123 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func TestAccResourceNsxtFirewallSectionOrdering_basic(t *testing.T) {
+	sectionNames := [2]string{getAccTestResourceName(), getAccTestResourceName()}
+	testResourceName := "nsxt_firewall_section_ordering.test"
+
+	// Section ordering is global, hence the test is not run in parallel
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			for _, name := range sectionNames {
+				if err := testAccNSXFirewallSectionCheckDestroy(state, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXFirewallSectionOrderingTemplate(sectionNames, "test2", "test1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "section_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(testResourceName, "section_ids.0", "nsxt_firewall_section.test2", "id"),
+					resource.TestCheckResourceAttrPair(testResourceName, "section_ids.1", "nsxt_firewall_section.test1", "id"),
+				),
+			},
+			{
+				Config: testAccNSXFirewallSectionOrderingTemplate(sectionNames, "test1", "test2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "section_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(testResourceName, "section_ids.0", "nsxt_firewall_section.test1", "id"),
+					resource.TestCheckResourceAttrPair(testResourceName, "section_ids.1", "nsxt_firewall_section.test2", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetFirewallSectionMoves(t *testing.T) {
+	cases := []struct {
+		current  []string
+		desired  []string
+		expected []firewallSectionMove
+	}{
+		{
+			current: []string{"a", "x", "b", "c"},
+			desired: []string{"a", "b", "c"},
+		},
+		{
+			current:  []string{"c", "x", "b", "a"},
+			desired:  []string{"a", "b", "c"},
+			expected: []firewallSectionMove{{SectionID: "b", AnchorID: "a"}, {SectionID: "c", AnchorID: "b"}},
+		},
+		{
+			current:  []string{"b", "a", "x", "c"},
+			desired:  []string{"a", "b", "c"},
+			expected: []firewallSectionMove{{SectionID: "b", AnchorID: "a"}},
+		},
+		{
+			current:  []string{"a", "c", "b"},
+			desired:  []string{"a", "b", "c"},
+			expected: []firewallSectionMove{{SectionID: "c", AnchorID: "b"}},
+		},
+	}
+
+	for _, c := range cases {
+		moves := getFirewallSectionMoves(c.current, c.desired)
+		if !reflect.DeepEqual(moves, c.expected) {
+			t.Errorf("Expected moves %v for %v -> %v, got %v", c.expected, c.current, c.desired, moves)
+		}
+	}
+}
+
+func TestValidateFirewallSectionsForOrdering(t *testing.T) {
+	sections := []manager.FirewallSection{
+		{Id: "s1", SectionType: "LAYER3"},
+		{Id: "s2", SectionType: "LAYER3"},
+		{Id: "s3", SectionType: "LAYER2"},
+		{Id: "default", SectionType: "LAYER3", IsDefault: true},
+	}
+	if err := validateFirewallSectionsForOrdering(sections, []string{"s2", "s1"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := validateFirewallSectionsForOrdering(sections, []string{"s1", "s3"}); err == nil {
+		t.Errorf("Expected error for sections of different types")
+	}
+	if err := validateFirewallSectionsForOrdering(sections, []string{"s1", "default"}); err == nil {
+		t.Errorf("Expected error for default section")
+	}
+}
+
+func testAccNSXFirewallSectionOrderingTemplate(names [2]string, first string, second string) string {
+	return fmt.Sprintf(`
+resource "nsxt_firewall_section" "test1" {
+  display_name = "%s"
+  section_type = "LAYER3"
+  stateful     = true
+}
+
+resource "nsxt_firewall_section" "test2" {
+  display_name = "%s"
+  section_type = "LAYER3"
+  stateful     = true
+}
+
+resource "nsxt_firewall_section_ordering" "test" {
+  section_ids = [nsxt_firewall_section.%s.id, nsxt_firewall_section.%s.id]
+}`, names[0], names[1], first, second)
+}
@@ -4,12 +4,21 @@
 package nsxt
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	api "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/common"
+	"github.com/vmware/go-vmware-nsxt/manager"
 )
 
 func TestAccResourceNsxtFirewallSection_basic(t *testing.T) {
@@ -35,6 +44,7 @@ func TestAccResourceNsxtFirewallSection_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(testResourceName, "description", "Acceptance Test"),
 					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
 					resource.TestCheckResourceAttr(testResourceName, "section_type", "LAYER3"),
+					resource.TestCheckResourceAttr(testResourceName, "cascade", "true"),
 					resource.TestCheckResourceAttr(testResourceName, "stateful", "true"),
 					resource.TestCheckResourceAttr(testResourceName, "rule.#", "0"),
 					resource.TestCheckResourceAttr(testResourceName, "applied_to.#", "0"),
@@ -119,6 +129,7 @@ func TestAccResourceNsxtFirewallSection_withRules(t *testing.T) {
 	tags := singleTag
 	tos := ""
 	ruleTos := ""
+	var ruleID string
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
@@ -137,6 +148,8 @@ func TestAccResourceNsxtFirewallSection_withRules(t *testing.T) {
 					resource.TestCheckResourceAttr(testResourceName, "stateful", "true"),
 					resource.TestCheckResourceAttr(testResourceName, "rule.#", "1"),
 					resource.TestCheckResourceAttr(testResourceName, "rule.0.display_name", ruleName),
+					resource.TestCheckResourceAttrSet(testResourceName, "rule.0.id"),
+					testAccNSXFirewallSectionRuleID(testResourceName, 0, &ruleID),
 					resource.TestCheckResourceAttr(testResourceName, "rule.0.applied_to.#", "0"),
 					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
 					resource.TestCheckResourceAttr(testResourceName, "applied_to.#", "0"),
@@ -152,6 +165,7 @@ func TestAccResourceNsxtFirewallSection_withRules(t *testing.T) {
 					resource.TestCheckResourceAttr(testResourceName, "stateful", "true"),
 					resource.TestCheckResourceAttr(testResourceName, "rule.#", "2"),
 					resource.TestCheckResourceAttr(testResourceName, "rule.0.display_name", updatedRuleName),
+					testAccNSXFirewallSectionRuleID(testResourceName, 0, &ruleID),
 					resource.TestCheckResourceAttr(testResourceName, "applied_to.#", "0"),
 					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
 				),
@@ -160,6 +174,52 @@ func TestAccResourceNsxtFirewallSection_withRules(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtFirewallSection_ruleChangedOutOfBand(t *testing.T) {
+	sectionName := getAccTestResourceName()
+	testResourceName := "nsxt_firewall_section.test"
+	ruleName := "rule1.0"
+	var sectionID string
+	var ruleID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXFirewallSectionCheckDestroy(state, sectionName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXFirewallSectionCreateTemplate(sectionName, ruleName, singleTag, "", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					testAccNSXFirewallSectionID(testResourceName, &sectionID),
+					testAccNSXFirewallSectionRuleID(testResourceName, 0, &ruleID),
+				),
+			},
+			{
+				// Fields not visible in the UI rule grid are modified on NSX, plan must detect it
+				PreConfig: func() {
+					if err := testAccNSXFirewallSectionModifyRule(sectionID, ruleID); err != nil {
+						panic(err)
+					}
+				},
+				Config:             testAccNSXFirewallSectionCreateTemplate(sectionName, ruleName, singleTag, "", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccNSXFirewallSectionCreateTemplate(sectionName, ruleName, singleTag, "", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionRuleID(testResourceName, 0, &ruleID),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.notes", "test rule"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.logged", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.direction", "IN"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtFirewallSection_withRulesAndTags(t *testing.T) {
 	sectionName := getAccTestResourceName()
 	testResourceName := "nsxt_firewall_section.test"
@@ -272,6 +332,105 @@ applied_to {
 	})
 }
 
+func TestAccResourceNsxtFirewallSection_withRuleTos(t *testing.T) {
+	sectionName := getAccTestResourceName()
+	testResourceName := "nsxt_firewall_section.test"
+	ruleName := "rule1.0"
+	ruleTos := `applied_to {
+  target_type = "NSGroup"
+  target_id   = "${nsxt_ns_group.grp5.id}"
+}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXFirewallSectionCheckDestroy(state, sectionName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXFirewallSectionCreateTemplate(sectionName, ruleName, singleTag, "", ruleTos),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "applied_to.#", "0"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.applied_to.#", "1"),
+					testAccNSXFirewallSectionRuleAppliedToCount(testResourceName, 0, 1),
+				),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtFirewallSection_removeRules(t *testing.T) {
+	sectionName := getAccTestResourceName()
+	testResourceName := "nsxt_firewall_section.test"
+	ruleName := "rule1.0"
+	tags := singleTag
+	tos := ""
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXFirewallSectionCheckDestroy(state, sectionName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXFirewallSectionUpdateTemplate(sectionName, ruleName, tags, tos),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "2"),
+				),
+			},
+			{
+				Config: testAccNSXFirewallSectionUpdateEmptyTemplate(sectionName, tags, tos),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					testAccNSXFirewallSectionRuleCount(testResourceName, 0),
+					resource.TestCheckResourceAttr(testResourceName, "description", "Acceptance Test Update"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtFirewallSection_manyRules(t *testing.T) {
+	sectionName := getAccTestResourceName()
+	testResourceName := "nsxt_firewall_section.test"
+	ruleCount := 20
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXFirewallSectionCheckDestroy(state, sectionName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXFirewallSectionManyRulesTemplate(sectionName, ruleCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", sectionName),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", fmt.Sprintf("%d", ruleCount)),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.display_name", "rule0"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.19.display_name", "rule19"),
+				),
+			},
+			{
+				Config:   testAccNSXFirewallSectionManyRulesTemplate(sectionName, ruleCount),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtFirewallSection_ordered(t *testing.T) {
 	sectionNames := [4]string{getAccTestResourceName(), getAccTestResourceName(), getAccTestResourceName(), getAccTestResourceName()}
 	testResourceNames := [4]string{"nsxt_firewall_section.test1", "nsxt_firewall_section.test2", "nsxt_firewall_section.test3", "nsxt_firewall_section.test4"}
@@ -325,6 +484,38 @@ func TestAccResourceNsxtFirewallSection_ordered(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtFirewallSection_insertOperation(t *testing.T) {
+	sectionNames := [3]string{getAccTestResourceName(), getAccTestResourceName(), getAccTestResourceName()}
+	testResourceNames := [3]string{"nsxt_firewall_section.test1", "nsxt_firewall_section.test2", "nsxt_firewall_section.test3"}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			for i := 0; i <= 2; i++ {
+				err := testAccNSXFirewallSectionCheckDestroy(state, sectionNames[i])
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXFirewallSectionInsertOperationTemplate(sectionNames),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionNames[0], testResourceNames[0]),
+					testAccNSXFirewallSectionExists(sectionNames[1], testResourceNames[1]),
+					resource.TestCheckResourceAttr(testResourceNames[1], "operation", "insert_after"),
+					testAccNSXFirewallSectionExists(sectionNames[2], testResourceNames[2]),
+					resource.TestCheckResourceAttr(testResourceNames[2], "operation", "insert_top"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtFirewallSection_edge(t *testing.T) {
 	sectionName := getAccTestResourceName()
 	edgeClusterName := getEdgeClusterName()
@@ -376,6 +567,171 @@ func TestAccResourceNsxtFirewallSection_edge(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtFirewallSection_layer2IPSet(t *testing.T) {
+	sectionName := getAccTestResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXFirewallSectionCheckDestroy(state, sectionName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXFirewallSectionLayer2IPSetTemplate(sectionName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Rule 0 .* references IPSet .* which is not supported for this section type`),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtFirewallSection_layer2Stateful(t *testing.T) {
+	sectionName := getAccTestResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXFirewallSectionLayer2StatefulTemplate(sectionName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`LAYER2 firewall sections can only be stateless`),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtFirewallSection_missingService(t *testing.T) {
+	sectionName := getAccTestResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXFirewallSectionCheckDestroy(state, sectionName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXFirewallSectionMissingServiceTemplate(sectionName),
+				ExpectError: regexp.MustCompile(`references NSService .*, which does not exist`),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtFirewallSection_waitForRealization(t *testing.T) {
+	sectionName := getAccTestResourceName()
+	testResourceName := "nsxt_firewall_section.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXFirewallSectionCheckDestroy(state, sectionName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXFirewallSectionWaitForRealizationTemplate(sectionName, "ALLOW"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "wait_for_realization", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.action", "ALLOW"),
+				),
+			},
+			{
+				Config: testAccNSXFirewallSectionWaitForRealizationTemplate(sectionName, "DROP"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.action", "DROP"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtFirewallSection_sectionLogged(t *testing.T) {
+	sectionName := getAccTestResourceName()
+	testResourceName := "nsxt_firewall_section.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXFirewallSectionCheckDestroy(state, sectionName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXFirewallSectionLoggedTemplate(sectionName, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "logged", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.#", "2"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.logged", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.1.logged", "false"),
+				),
+			},
+			{
+				// Rule that no longer specifies logged inherits the section flag
+				Config: testAccNSXFirewallSectionLoggedTemplate(sectionName, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "logged", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.logged", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.1.logged", "true"),
+				),
+			},
+			{
+				Config: testAccNSXFirewallSectionLoggedTemplate(sectionName, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "logged", "false"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.logged", "false"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.1.logged", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtFirewallSection_statelessDirection(t *testing.T) {
+	sectionName := getAccTestResourceName()
+	testResourceName := "nsxt_firewall_section.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXFirewallSectionCheckDestroy(state, sectionName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXFirewallSectionStatelessDirectionTemplate(sectionName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.direction", "IN_OUT"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.1.direction", "OUT"),
+				),
+			},
+			{
+				// Rule that no longer specifies direction defaults to IN_OUT
+				Config: testAccNSXFirewallSectionStatelessDirectionTemplate(sectionName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXFirewallSectionExists(sectionName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "rule.0.direction", "IN_OUT"),
+					resource.TestCheckResourceAttr(testResourceName, "rule.1.direction", "IN_OUT"),
+				),
+			},
+			{
+				Config:   testAccNSXFirewallSectionStatelessDirectionTemplate(sectionName, false),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtFirewallSection_importBasic(t *testing.T) {
 	sectionName := getAccTestResourceName()
 	testResourceName := "nsxt_firewall_section.test"
@@ -456,9 +812,22 @@ target_id = "${nsxt_ns_group.grp1.id}"
 }
 
 func testAccNSXFirewallSectionExists(displayName string, resourceName string) resource.TestCheckFunc {
-	return func(state *terraform.State) error {
+	return resource.ComposeTestCheckFunc(
+		testAccNSXResourceExists(resourceName, displayName, testAccNSXFirewallSectionPresence),
+		resource.TestCheckResourceAttr(resourceName, "display_name", displayName),
+	)
+}
 
-		nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
+func testAccNSXFirewallSectionPresence(nsxClient *api.APIClient, rs *terraform.ResourceState) (string, bool, error) {
+	section, resp, err := nsxClient.ServicesApi.GetSection(nsxClient.Context, rs.Primary.ID)
+	exists, err := testAccNSXResourcePresence(resp, err)
+	return section.DisplayName, exists, err
+}
+
+func testAccNSXFirewallSectionRuleCount(resourceName string, count int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
 
 		rs, ok := state.RootModule().Resources[resourceName]
 		if !ok {
@@ -466,51 +835,104 @@ func testAccNSXFirewallSectionExists(displayName string, resourceName string) re
 		}
 
 		resourceID := rs.Primary.ID
-		if resourceID == "" {
-			return fmt.Errorf("Firewall Section resource ID not set in resources ")
-		}
-
-		profile, responseCode, err := nsxClient.ServicesApi.GetSection(nsxClient.Context, resourceID)
+		section, _, err := nsxClient.ServicesApi.GetSectionWithRulesListWithRules(nsxClient.Context, resourceID)
 		if err != nil {
 			return fmt.Errorf("Error while retrieving firewall section ID %s. Error: %v", resourceID, err)
 		}
 
-		if responseCode.StatusCode != http.StatusOK {
-			return fmt.Errorf("Error while checking if firewall section %s exists. HTTP return code was %d", resourceID, responseCode.StatusCode)
-		}
-
-		if displayName == profile.DisplayName {
-			return nil
+		if len(section.Rules) != count {
+			return fmt.Errorf("Firewall Section %s has %d rules on NSX, expected %d", resourceID, len(section.Rules), count)
 		}
-		return fmt.Errorf("Firewall Section %s wasn't found", displayName)
+		return nil
 	}
 }
 
-func testAccNSXFirewallSectionCheckDestroy(state *terraform.State, displayName string) error {
-	nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
+// testAccNSXFirewallSectionRuleAppliedToCount verifies rule level applied_to
+// on NSX side, since section level applied_to would not show there
+func testAccNSXFirewallSectionRuleAppliedToCount(resourceName string, index int, count int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
 
-	for _, rs := range state.RootModule().Resources {
+		nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
 
-		if rs.Type != "nsxt_firewall_section" {
-			continue
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Firewall Section resource %s not found in resources", resourceName)
 		}
 
-		resourceID := rs.Primary.Attributes["id"]
-		profile, responseCode, err := nsxClient.ServicesApi.GetSection(nsxClient.Context, resourceID)
+		resourceID := rs.Primary.ID
+		section, _, err := nsxClient.ServicesApi.GetSectionWithRulesListWithRules(nsxClient.Context, resourceID)
 		if err != nil {
-			if responseCode.StatusCode != http.StatusOK {
-				return nil
-			}
 			return fmt.Errorf("Error while retrieving firewall section ID %s. Error: %v", resourceID, err)
 		}
 
-		if displayName == profile.DisplayName {
-			return fmt.Errorf("Firewall Section %s still exists", displayName)
+		if len(section.Rules) <= index {
+			return fmt.Errorf("Firewall Section %s has %d rules on NSX, expected rule %d", resourceID, len(section.Rules), index)
+		}
+		if len(section.Rules[index].AppliedTos) != count {
+			return fmt.Errorf("Firewall Section %s rule %d has %d applied_to on NSX, expected %d", resourceID, index, len(section.Rules[index].AppliedTos), count)
+		}
+		return nil
+	}
+}
+
+// testAccNSXFirewallSectionRuleID records the id of the rule at given index on
+// first call, and verifies the id did not change on subsequent calls
+func testAccNSXFirewallSectionRuleID(resourceName string, index int, ruleID *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Firewall Section resource %s not found in resources", resourceName)
+		}
+
+		currentID := rs.Primary.Attributes[fmt.Sprintf("rule.%d.id", index)]
+		if currentID == "" {
+			return fmt.Errorf("Firewall Section rule %d has no id", index)
+		}
+		if *ruleID == "" {
+			*ruleID = currentID
+		} else if *ruleID != currentID {
+			return fmt.Errorf("Firewall Section rule %d id changed from %s to %s", index, *ruleID, currentID)
+		}
+		return nil
+	}
+}
+
+func testAccNSXFirewallSectionID(resourceName string, sectionID *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Firewall Section resource %s not found in resources", resourceName)
 		}
+		*sectionID = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccNSXFirewallSectionModifyRule(sectionID string, ruleID string) error {
+	nsxClient, err := testAccGetClient()
+	if err != nil {
+		return fmt.Errorf("Error during test client initialization: %v", err)
+	}
+
+	rule, _, err := nsxClient.ServicesApi.GetRule(nsxClient.Context, sectionID, ruleID)
+	if err != nil {
+		return fmt.Errorf("Error while retrieving firewall rule %s: %v", ruleID, err)
+	}
+
+	rule.Notes = "changed out of band"
+	rule.Logged = !rule.Logged
+	rule.Direction = "OUT"
+	_, _, err = nsxClient.ServicesApi.UpdateRule(nsxClient.Context, sectionID, ruleID, rule)
+	if err != nil {
+		return fmt.Errorf("Error while updating firewall rule %s: %v", ruleID, err)
 	}
 	return nil
 }
 
+func testAccNSXFirewallSectionCheckDestroy(state *terraform.State, displayName string) error {
+	return testAccNSXResourceCheckDestroy(state, displayName, "nsxt_firewall_section", testAccNSXFirewallSectionPresence)
+}
+
 func testAccNSXFirewallSectionNSGroups() string {
 	return `
 resource "nsxt_ns_group" "grp1" {
@@ -709,6 +1131,31 @@ resource "nsxt_firewall_section" "test4" {
 `, names[0], names[1], names[2], names[3])
 }
 
+func testAccNSXFirewallSectionInsertOperationTemplate(names [3]string) string {
+	return fmt.Sprintf(`
+resource "nsxt_firewall_section" "test1" {
+  display_name = "%s"
+  section_type = "LAYER3"
+  stateful     = true
+}
+
+resource "nsxt_firewall_section" "test2" {
+  display_name      = "%s"
+  section_type      = "LAYER3"
+  operation         = "insert_after"
+  anchor_section_id = nsxt_firewall_section.test1.id
+  stateful          = true
+}
+
+resource "nsxt_firewall_section" "test3" {
+  display_name = "%s"
+  section_type = "LAYER3"
+  operation    = "insert_top"
+  stateful     = true
+}
+`, names[0], names[1], names[2])
+}
+
 func testAccNSXEdgeFirewallSectionCreateTemplate(edgeCluster string, transportZone string, name string, ruleName string) string {
 	return fmt.Sprintf(`
 
@@ -764,3 +1211,640 @@ resource "nsxt_firewall_section" "test" {
   }
 }`, edgeCluster, transportZone, name, ruleName)
 }
+
+func testAccNSXFirewallSectionManyRulesTemplate(name string, ruleCount int) string {
+	rules := ""
+	for i := 0; i < ruleCount; i++ {
+		rules += fmt.Sprintf(`
+  rule {
+    display_name = "rule%d"
+    action       = "ALLOW"
+    ip_protocol  = "IPV4"
+    rule_tag     = "tag%d"
+  }
+`, i, i)
+	}
+
+	return fmt.Sprintf(`
+resource "nsxt_firewall_section" "test" {
+  display_name = "%s"
+  section_type = "LAYER3"
+  stateful     = true
+%s
+}`, name, rules)
+}
+
+func testAccNSXFirewallSectionLayer2IPSetTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "nsxt_ip_set" "test" {
+  display_name = "%s"
+  ip_addresses = ["1.1.1.1"]
+}
+
+resource "nsxt_firewall_section" "test" {
+  display_name = "%s"
+  section_type = "LAYER2"
+  stateful     = false
+
+  rule {
+    display_name = "test"
+    action       = "ALLOW"
+
+    source {
+      target_id   = "${nsxt_ip_set.test.id}"
+      target_type = "IPSet"
+    }
+  }
+}`, name, name)
+}
+
+func testAccNSXFirewallSectionLayer2StatefulTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "nsxt_firewall_section" "test" {
+  display_name = "%s"
+  section_type = "LAYER2"
+  stateful     = true
+}`, name)
+}
+
+func testAccNSXFirewallSectionMissingServiceTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "nsxt_firewall_section" "test" {
+  display_name = "%s"
+  section_type = "LAYER3"
+  stateful     = true
+
+  rule {
+    display_name = "rule1"
+    action       = "ALLOW"
+    direction    = "IN_OUT"
+
+    service {
+      target_type = "NSService"
+      target_id   = "00000000-0000-0000-0000-000000000000"
+    }
+  }
+}`, name)
+}
+
+func TestIsFirewallRuleServiceProtocolMismatch(t *testing.T) {
+	cases := []struct {
+		ipProtocol      string
+		serviceProtocol string
+		mismatch        bool
+	}{
+		{"IPV4", "ICMPv4", false},
+		{"IPV6", "ICMPv4", true},
+		{"IPV4_IPV6", "ICMPv4", false},
+		{"IPV4", "ICMPv6", true},
+		{"IPV6", "ICMPv6", false},
+		{"IPV4_IPV6", "ICMPv6", false},
+		{"IPV6", "", false},
+	}
+
+	for _, c := range cases {
+		if isFirewallRuleServiceProtocolMismatch(c.ipProtocol, c.serviceProtocol) != c.mismatch {
+			t.Errorf("Expected mismatch %v for ip_protocol %s and service protocol %s", c.mismatch, c.ipProtocol, c.serviceProtocol)
+		}
+	}
+}
+
+func TestGetIPAddressFamilies(t *testing.T) {
+	cases := []struct {
+		addresses []string
+		hasIPv4   bool
+		hasIPv6   bool
+	}{
+		{[]string{"10.0.0.1"}, true, false},
+		{[]string{"10.0.0.0/24", "10.0.1.1-10.0.1.10"}, true, false},
+		{[]string{"2001:db8::/64"}, false, true},
+		{[]string{"2001:db8::1-2001:db8::10", "10.0.0.1"}, true, true},
+		{[]string{}, false, false},
+	}
+
+	for _, c := range cases {
+		hasIPv4, hasIPv6 := getIPAddressFamilies(c.addresses)
+		if hasIPv4 != c.hasIPv4 || hasIPv6 != c.hasIPv6 {
+			t.Errorf("Expected IPv4 %v and IPv6 %v for %v, got %v and %v", c.hasIPv4, c.hasIPv6, c.addresses, hasIPv4, hasIPv6)
+		}
+	}
+}
+
+func TestIsFirewallRuleIPProtocolMismatch(t *testing.T) {
+	cases := []struct {
+		ipProtocol string
+		hasIPv4    bool
+		hasIPv6    bool
+		mismatch   bool
+	}{
+		{"IPV4", true, false, false},
+		{"IPV4", false, true, true},
+		{"IPV4", true, true, false},
+		{"IPV6", true, false, true},
+		{"IPV6", false, true, false},
+		{"IPV4_IPV6", true, false, false},
+		{"IPV6", false, false, false},
+	}
+
+	for _, c := range cases {
+		if isFirewallRuleIPProtocolMismatch(c.ipProtocol, c.hasIPv4, c.hasIPv6) != c.mismatch {
+			t.Errorf("Expected mismatch %v for ip_protocol %s with IPv4 %v and IPv6 %v", c.mismatch, c.ipProtocol, c.hasIPv4, c.hasIPv6)
+		}
+	}
+}
+
+func testAccNSXFirewallSectionWaitForRealizationTemplate(name string, action string) string {
+	return fmt.Sprintf(`
+resource "nsxt_firewall_section" "test" {
+  display_name         = "%s"
+  section_type         = "LAYER3"
+  stateful             = true
+  wait_for_realization = true
+
+  rule {
+    display_name = "rule1"
+    action       = "%s"
+    direction    = "IN_OUT"
+  }
+}`, name, action)
+}
+
+func testAccNSXFirewallSectionLoggedTemplate(name string, logged bool, explicitRuleLogged bool) string {
+	ruleLogged := ""
+	if explicitRuleLogged {
+		ruleLogged = "logged       = false"
+	}
+	return fmt.Sprintf(`
+resource "nsxt_firewall_section" "test" {
+  display_name = "%s"
+  section_type = "LAYER3"
+  stateful     = true
+  logged       = %t
+
+  rule {
+    display_name = "rule1"
+    action       = "ALLOW"
+    direction    = "IN_OUT"
+  }
+
+  rule {
+    display_name = "rule2"
+    action       = "ALLOW"
+    direction    = "IN_OUT"
+    %s
+  }
+}`, name, logged, ruleLogged)
+}
+
+func testAccNSXFirewallSectionStatelessDirectionTemplate(name string, explicitRuleDirection bool) string {
+	ruleDirection := ""
+	if explicitRuleDirection {
+		ruleDirection = `direction    = "OUT"`
+	}
+	return fmt.Sprintf(`
+resource "nsxt_firewall_section" "test" {
+  display_name = "%s"
+  section_type = "LAYER3"
+  stateful     = false
+
+  rule {
+    display_name = "rule1"
+    action       = "ALLOW"
+  }
+
+  rule {
+    display_name = "rule2"
+    action       = "ALLOW"
+    %s
+  }
+}`, name, ruleDirection)
+}
+
+func TestOrderRulesByState(t *testing.T) {
+	stateRule := func(id string, name string) interface{} {
+		return map[string]interface{}{"id": id, "display_name": name, "rule_tag": ""}
+	}
+	stateRules := []interface{}{
+		stateRule("1", "a"),
+		stateRule("2", "b"),
+		stateRule("", "c"),
+		stateRule("4", "d"),
+	}
+	cases := []struct {
+		description string
+		nsxRules    []manager.FirewallRule
+		expected    []string
+	}{
+		{"same order", []manager.FirewallRule{{Id: "1"}, {Id: "2"}, {Id: "3", DisplayName: "c"}, {Id: "4"}}, []string{"1", "2", "3", "4"}},
+		{"reordered on NSX", []manager.FirewallRule{{Id: "4"}, {Id: "3", DisplayName: "c"}, {Id: "1"}, {Id: "2"}}, []string{"4", "3", "1", "2"}},
+		{"reordered and renumbered on NSX", []manager.FirewallRule{{Id: "5", DisplayName: "b"}, {Id: "1"}, {Id: "x"}, {Id: "4"}}, []string{"5", "1", "x", "4"}},
+		{"renumbered on NSX", []manager.FirewallRule{{Id: "1"}, {Id: "5", DisplayName: "b"}, {Id: "3", DisplayName: "c"}, {Id: "4"}}, []string{"1", "5", "3", "4"}},
+		{"unknown rule on top", []manager.FirewallRule{{Id: "x"}, {Id: "1"}, {Id: "2"}, {Id: "3", DisplayName: "c"}, {Id: "4"}}, []string{"x", "1", "2", "3", "4"}},
+		{"unknown rule in the middle", []manager.FirewallRule{{Id: "1"}, {Id: "2"}, {Id: "x"}, {Id: "3", DisplayName: "c"}, {Id: "4"}}, []string{"1", "2", "x", "3", "4"}},
+		{"deleted rule", []manager.FirewallRule{{Id: "1"}, {Id: "3", DisplayName: "c"}, {Id: "4"}}, []string{"1", "3", "4"}},
+		// Name match must not claim a rule matched by id to another state rule
+		{"id takes precedence", []manager.FirewallRule{{Id: "1", DisplayName: "c"}, {Id: "2"}, {Id: "3", DisplayName: "c"}, {Id: "4"}}, []string{"1", "2", "3", "4"}},
+	}
+
+	for _, c := range cases {
+		var order []string
+		for _, rule := range orderRulesByState(stateRules, c.nsxRules) {
+			order = append(order, rule.Id)
+		}
+		if !reflect.DeepEqual(order, c.expected) {
+			t.Errorf("%s: expected order %v, got %v", c.description, c.expected, order)
+		}
+	}
+}
+
+func TestFirewallSectionRulesSchemaRoundTrip(t *testing.T) {
+	// Every rule field populated from NSX must make it back to the request,
+	// otherwise changes made outside of terraform to that field are lost
+	rule := manager.FirewallRule{
+		Id:                   "rule-1",
+		DisplayName:          "rule",
+		Description:          "description",
+		RuleTag:              "tag",
+		Notes:                "notes",
+		Logged:               true,
+		Action:               "DROP",
+		DestinationsExcluded: true,
+		SourcesExcluded:      true,
+		IpProtocol:           "IPV4",
+		Disabled:             true,
+		Revision:             3,
+		Direction:            "OUT",
+		Sources:              []common.ResourceReference{{TargetId: "src", TargetType: "NSGroup", IsValid: true}},
+		Destinations:         []common.ResourceReference{{TargetId: "dst", TargetType: "IPSet", IsValid: true}},
+		Services:             []manager.FirewallService{{TargetId: "svc", TargetType: "NSService", IsValid: true}},
+		AppliedTos:           []common.ResourceReference{{TargetId: "port", TargetType: "LogicalPort", IsValid: true}},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceNsxtFirewallSection().Schema, map[string]interface{}{})
+	if err := setRulesInSchema(d, []manager.FirewallRule{rule}); err != nil {
+		t.Fatalf("Failed to set rules in schema: %v", err)
+	}
+
+	rules := getRulesFromSchema(d)
+	if len(rules) != 1 {
+		t.Fatalf("Expected 1 rule, got %d", len(rules))
+	}
+	if !reflect.DeepEqual(rules[0], rule) {
+		t.Errorf("Rule changed after schema round trip:\nexpected %+v\ngot      %+v", rule, rules[0])
+	}
+}
+
+func TestDiffFirewallRules(t *testing.T) {
+	oldRules := []manager.FirewallRule{
+		{Id: "rule-1", Revision: 1, DisplayName: "rule1", Action: "ALLOW"},
+		{Id: "rule-2", Revision: 4, DisplayName: "rule2", Action: "ALLOW"},
+		{Id: "rule-3", Revision: 2, DisplayName: "rule3", Action: "ALLOW"},
+	}
+
+	// Unchanged rules are not touched, changed rule keeps id and revision
+	newRules := []manager.FirewallRule{
+		{Id: "rule-1", DisplayName: "rule1", Action: "ALLOW"},
+		{Id: "rule-2", DisplayName: "rule2", Action: "DROP"},
+		{Id: "rule-3", DisplayName: "rule3", Action: "ALLOW"},
+		{DisplayName: "rule4", Action: "ALLOW"},
+	}
+	toUpdate, toAdd, toDelete, ok := diffFirewallRules(oldRules, newRules)
+	if !ok {
+		t.Fatalf("Expected incremental update to be possible")
+	}
+	if len(toUpdate) != 1 || toUpdate[0].Id != "rule-2" || toUpdate[0].Revision != 4 || toUpdate[0].Action != "DROP" {
+		t.Errorf("Unexpected rules to update: %+v", toUpdate)
+	}
+	if len(toAdd) != 1 || toAdd[0].DisplayName != "rule4" || toAdd[0].Id != "" {
+		t.Errorf("Unexpected rules to add: %+v", toAdd)
+	}
+	if len(toDelete) != 0 {
+		t.Errorf("Unexpected rules to delete: %v", toDelete)
+	}
+
+	// Trailing rules removed from config are deleted
+	toUpdate, toAdd, toDelete, ok = diffFirewallRules(oldRules, newRules[:1])
+	if !ok || len(toUpdate) != 0 || len(toAdd) != 0 || !reflect.DeepEqual(toDelete, []string{"rule-2", "rule-3"}) {
+		t.Errorf("Unexpected diff on rule removal: %+v %+v %v %v", toUpdate, toAdd, toDelete, ok)
+	}
+
+	// Rules without id in state require full section update
+	_, _, _, ok = diffFirewallRules([]manager.FirewallRule{{DisplayName: "rule1"}}, newRules)
+	if ok {
+		t.Errorf("Expected full update for rules without id")
+	}
+
+	// Rule inserted at the top shifts positional ids, and requires full
+	// section update with ids of existing rules preserved
+	insertedRules := []manager.FirewallRule{
+		{Id: "rule-1", DisplayName: "rule0", Action: "DROP"},
+		{Id: "rule-2", DisplayName: "rule1", Action: "ALLOW"},
+		{Id: "rule-3", DisplayName: "rule2", Action: "ALLOW"},
+		{DisplayName: "rule3", Action: "ALLOW"},
+	}
+	if _, _, _, ok = diffFirewallRules(oldRules, insertedRules); ok {
+		t.Errorf("Expected full update for rule inserted at the top")
+	}
+	matchedRules, _ := matchFirewallRulesByID(oldRules, insertedRules)
+	expectedIDs := []string{"", "rule-1", "rule-2", "rule-3"}
+	for i, rule := range matchedRules {
+		if rule.Id != expectedIDs[i] {
+			t.Errorf("Expected rule %s to have id %q, got %q", rule.DisplayName, expectedIDs[i], rule.Id)
+		}
+	}
+	if matchedRules[3].Revision != 2 {
+		t.Errorf("Expected rule3 to keep revision 2, got %d", matchedRules[3].Revision)
+	}
+
+	// Reordered rules require full section update
+	reorderedRules := []manager.FirewallRule{
+		{Id: "rule-1", DisplayName: "rule2", Action: "ALLOW"},
+		{Id: "rule-2", DisplayName: "rule1", Action: "ALLOW"},
+		{Id: "rule-3", DisplayName: "rule3", Action: "ALLOW"},
+	}
+	if _, _, _, ok = diffFirewallRules(oldRules, reorderedRules); ok {
+		t.Errorf("Expected full update for reordered rules")
+	}
+
+	// Rule removed from the middle is deleted, following rules are matched
+	// by name rather than by shifted positional id
+	toUpdate, toAdd, toDelete, ok = diffFirewallRules(oldRules, []manager.FirewallRule{
+		{Id: "rule-1", DisplayName: "rule1", Action: "ALLOW"},
+		{Id: "rule-2", DisplayName: "rule3", Action: "ALLOW"},
+	})
+	if !ok || len(toUpdate) != 0 || len(toAdd) != 0 || !reflect.DeepEqual(toDelete, []string{"rule-2"}) {
+		t.Errorf("Unexpected diff on rule removal from the middle: %+v %+v %v %v", toUpdate, toAdd, toDelete, ok)
+	}
+}
+
+func TestValidateFirewallSectionOperation(t *testing.T) {
+	for _, operation := range []string{"insert_before", "insert_after"} {
+		if err := validateFirewallSectionOperation(operation, ""); err == nil {
+			t.Errorf("Expected %s operation without anchor to be invalid", operation)
+		}
+		if err := validateFirewallSectionOperation(operation, "section-1"); err != nil {
+			t.Errorf("Expected %s operation with anchor to be valid, got error: %v", operation, err)
+		}
+	}
+	for _, operation := range []string{"", "insert_top", "insert_bottom"} {
+		if err := validateFirewallSectionOperation(operation, ""); err != nil {
+			t.Errorf("Expected %q operation without anchor to be valid, got error: %v", operation, err)
+		}
+	}
+}
+
+func TestValidateFirewallSectionStateful(t *testing.T) {
+	if err := validateFirewallSectionStateful("LAYER2", true); err == nil {
+		t.Errorf("Expected stateful LAYER2 section to be invalid")
+	}
+	for _, sectionType := range []string{"LAYER2", "LAYER3"} {
+		if err := validateFirewallSectionStateful(sectionType, false); err != nil {
+			t.Errorf("Expected stateless %s section to be valid, got error: %v", sectionType, err)
+		}
+	}
+	if err := validateFirewallSectionStateful("LAYER3", true); err != nil {
+		t.Errorf("Expected stateful LAYER3 section to be valid, got error: %v", err)
+	}
+}
+
+func TestGetRulesWithIgnoredDirection(t *testing.T) {
+	rules := []manager.FirewallRule{
+		{DisplayName: "default"},
+		{DisplayName: "both", Direction: "IN_OUT"},
+		{DisplayName: "in", Direction: "IN"},
+	}
+	if ignored := getRulesWithIgnoredDirection(false, rules, nil); len(ignored) != 0 {
+		t.Errorf("Expected direction to be considered in stateless section, got %v", ignored)
+	}
+	ignored := getRulesWithIgnoredDirection(true, rules, nil)
+	if len(ignored) != 1 || ignored[0].DisplayName != "in" {
+		t.Errorf("Expected direction of rule 'in' to be ignored in stateful section, got %v", ignored)
+	}
+	// Direction not specified in configuration is refreshed from NSX
+	if ignored := getRulesWithIgnoredDirection(true, rules, map[int]bool{2: true}); len(ignored) != 0 {
+		t.Errorf("Expected direction not in configuration not to be reported, got %v", ignored)
+	}
+}
+
+func TestGetRulesFromSchemaDirection(t *testing.T) {
+	for _, stateful := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, resourceNsxtFirewallSection().Schema, map[string]interface{}{
+			"section_type": "LAYER3",
+			"stateful":     stateful,
+			"rule": []interface{}{
+				map[string]interface{}{"display_name": "default", "action": "ALLOW"},
+				map[string]interface{}{"display_name": "in", "action": "ALLOW", "direction": "IN"},
+			},
+		})
+		rules := getRulesFromSchema(d)
+		expected := []string{"IN_OUT", "IN"}
+		if stateful {
+			// NSX picks the direction for stateful sections
+			expected[0] = ""
+		}
+		for i, rule := range rules {
+			if rule.Direction != expected[i] {
+				t.Errorf("Expected direction %q for rule %s in section with stateful %v, got %q", expected[i], rule.DisplayName, stateful, rule.Direction)
+			}
+		}
+	}
+}
+
+func TestValidateRulesForSectionType(t *testing.T) {
+	ipSet := common.ResourceReference{TargetType: "IPSet", TargetId: "ipset1"}
+	macSet := common.ResourceReference{TargetType: "MACSet", TargetId: "macset1"}
+	group := common.ResourceReference{TargetType: "NSGroup", TargetId: "group1"}
+	rules := []manager.FirewallRule{
+		{DisplayName: "rule0", Sources: []common.ResourceReference{group}},
+		{DisplayName: "rule1", Destinations: []common.ResourceReference{group, ipSet}},
+	}
+
+	if err := validateRulesForSectionType("LAYER3", rules); err != nil {
+		t.Errorf("Unexpected error for IP set in LAYER3 section: %v", err)
+	}
+	err := validateRulesForSectionType("LAYER2", rules)
+	if err == nil || !strings.Contains(err.Error(), "Rule 1 ('rule1')") || !strings.Contains(err.Error(), "ipset1 in destination") {
+		t.Errorf("Expected error for IP set destination of rule 1 in LAYER2 section, got %v", err)
+	}
+
+	rules[0].Sources = append(rules[0].Sources, macSet)
+	err = validateRulesForSectionType("LAYER3", rules)
+	if err == nil || !strings.Contains(err.Error(), "Rule 0 ('rule0')") || !strings.Contains(err.Error(), "macset1 in source") {
+		t.Errorf("Expected error for MAC set source of rule 0 in LAYER3 section, got %v", err)
+	}
+}
+
+func TestFindFirewallSectionCreatedSince(t *testing.T) {
+	now := time.Now()
+	millis := func(t time.Time) int64 { return t.UnixNano() / int64(time.Millisecond) }
+	sections := []manager.FirewallSection{
+		{Id: "old", DisplayName: "section", CreateTime: millis(now.Add(-time.Hour))},
+		{Id: "new", DisplayName: "section", CreateTime: millis(now.Add(time.Second))},
+		{Id: "other", DisplayName: "other", CreateTime: millis(now.Add(time.Second))},
+	}
+
+	if id := findFirewallSectionCreatedSince(sections, "section", now); id != "new" {
+		t.Errorf("Expected section new to be found, got '%s'", id)
+	}
+	if id := findFirewallSectionCreatedSince(sections, "", now); id != "" {
+		t.Errorf("Expected no section for empty name, got '%s'", id)
+	}
+	// Ambiguous match is not adopted
+	sections = append(sections, manager.FirewallSection{Id: "new2", DisplayName: "section", CreateTime: millis(now)})
+	if id := findFirewallSectionCreatedSince(sections, "section", now); id != "" {
+		t.Errorf("Expected no section for ambiguous match, got '%s'", id)
+	}
+}
+
+func TestFirewallSectionCreateRollback(t *testing.T) {
+	deleteStatus := http.StatusOK
+	var deletePath string
+	nsxClient, closeServer := newTestManagerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		deletePath = r.URL.String()
+		w.WriteHeader(deleteStatus)
+	})
+	defer closeServer()
+
+	d := schema.TestResourceDataRaw(t, resourceNsxtFirewallSection().Schema, map[string]interface{}{})
+	createErr := fmt.Errorf("Error during FirewallSection create with rules")
+
+	// Partially created section is deleted along with its rules
+	d.SetId("section-1")
+	if err := resourceNsxtFirewallSectionCreateRollback(context.Background(), d, nsxClient, createErr); err != createErr || d.Id() != "" {
+		t.Errorf("Expected create error and section removed from state, got error %v and id %q", err, d.Id())
+	}
+	if deletePath != "/api/v1/firewall/sections/section-1?cascade=true" {
+		t.Errorf("Unexpected rollback request %s", deletePath)
+	}
+
+	// Section that failed to be deleted is kept in state to be replaced
+	deleteStatus = http.StatusInternalServerError
+	d.SetId("section-1")
+	if err := resourceNsxtFirewallSectionCreateRollback(context.Background(), d, nsxClient, createErr); err == nil || err == createErr || d.Id() != "section-1" {
+		t.Errorf("Expected rollback error and section kept in state, got error %v and id %q", err, d.Id())
+	}
+}
+
+func TestFirewallSectionUpdateErrorSectionDeleted(t *testing.T) {
+	sectionExists := true
+	sectionReads := 0
+	nsxClient, closeServer := newTestManagerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sectionReads++
+		w.Header().Set("Content-Type", "application/json")
+		if !sectionExists {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error_code": 202, "error_message": "The requested object could not be found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "section-1"}`)
+	})
+	defer closeServer()
+
+	d := schema.TestResourceDataRaw(t, resourceNsxtFirewallSection().Schema, map[string]interface{}{})
+	d.SetId("section-1")
+	message := "Error during FirewallSection section-1 rule rule-1 update"
+	badRequest := &http.Response{StatusCode: http.StatusBadRequest}
+	notFound := &http.Response{StatusCode: http.StatusNotFound}
+	updateErr := fmt.Errorf(`Status: 400 Bad Request, Body: {"error_code": 255, "error_message": "Invalid rule"}`)
+
+	// Failure that does not indicate a missing object is reported with NSX
+	// error details, without reading the section
+	err := resourceNsxtFirewallSectionUpdateError(context.Background(), d, nsxClient, message, badRequest, updateErr)
+	if err == nil || !strings.Contains(err.Error(), message) || !strings.Contains(err.Error(), "Invalid rule (code 255)") || sectionReads != 0 {
+		t.Errorf("Expected update error to be reported without reading the section, got %v after %d reads", err, sectionReads)
+	}
+
+	// Section still exists, hence not found response refers to the rule
+	err = resourceNsxtFirewallSectionUpdateError(context.Background(), d, nsxClient, message, notFound, nil)
+	if err == nil || !strings.Contains(err.Error(), message) || strings.Contains(err.Error(), "deleted outside of terraform") || d.Id() != "section-1" {
+		t.Errorf("Expected rule update error to be reported and section kept in state, got %v", err)
+	}
+
+	// Section was deleted outside of terraform, hence this is reported
+	// explicitly, and the section is removed from state on next refresh
+	sectionExists = false
+	for _, resp := range []*http.Response{notFound, nil} {
+		err = resourceNsxtFirewallSectionUpdateError(context.Background(), d, nsxClient, message, resp, nil)
+		if err == nil || !strings.Contains(err.Error(), "deleted outside of terraform") || d.Id() != "section-1" {
+			t.Errorf("Expected section deletion to be reported, got error %v and id %q", err, d.Id())
+		}
+	}
+}
+
+func TestFirewallSectionReadAppliedToFallback(t *testing.T) {
+	savedVersion := nsxVersion
+	nsxVersion = "2.1.0"
+	defer func() { nsxVersion = savedVersion }()
+
+	// Response of second call, that is made for NSX 2.1 and lower
+	sectionStatus := http.StatusOK
+	sectionBody := `{"id": "section-1", "applied_tos": [{"target_id": "nsgroup-1", "target_type": "NSGroup", "is_valid": true}]}`
+	nsxClient, closeServer := newTestManagerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("action") == "list_with_rules" {
+			fmt.Fprint(w, `{"id": "section-1", "display_name": "section1", "section_type": "LAYER3", "stateful": true}`)
+			return
+		}
+		w.WriteHeader(sectionStatus)
+		fmt.Fprint(w, sectionBody)
+	})
+	defer closeServer()
+	clients := nsxtClients{NsxtClient: nsxClient}
+
+	// applied_to is populated from the second call
+	d := schema.TestResourceDataRaw(t, resourceNsxtFirewallSection().Schema, map[string]interface{}{})
+	d.SetId("section-1")
+	if err := resourceNsxtFirewallSectionRead(context.Background(), d, clients); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	appliedTos := d.Get("applied_to").(*schema.Set).List()
+	if len(appliedTos) != 1 || appliedTos[0].(map[string]interface{})["target_id"].(string) != "nsgroup-1" {
+		t.Errorf("Expected applied_to to be populated from second call, got %v", d.Get("applied_to"))
+	}
+
+	// Second call without applied_tos clears the attribute
+	sectionBody = `{"id": "section-1"}`
+	if err := resourceNsxtFirewallSectionRead(context.Background(), d, clients); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d.Get("applied_to").(*schema.Set).Len() != 0 || d.Id() != "section-1" {
+		t.Errorf("Expected empty applied_to, got %v", d.Get("applied_to"))
+	}
+
+	// Section deleted between the two calls is removed from state
+	sectionStatus = http.StatusNotFound
+	sectionBody = `{"error_code": 202, "error_message": "The requested object could not be found"}`
+	if err := resourceNsxtFirewallSectionRead(context.Background(), d, clients); err != nil || d.Id() != "" {
+		t.Errorf("Expected section to be removed from state, got error %v and id %q", err, d.Id())
+	}
+}
+
+func TestFirewallSectionReadAppliedToSingleCall(t *testing.T) {
+	savedVersion := nsxVersion
+	nsxVersion = "2.1.0"
+	defer func() { nsxVersion = savedVersion }()
+
+	sectionCalls := 0
+	nsxClient, closeServer := newTestManagerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("action") == "list_with_rules" {
+			fmt.Fprint(w, `{"id": "section-1", "section_type": "LAYER3", "applied_tos": [{"target_id": "nsgroup-1", "target_type": "NSGroup"}]}`)
+			return
+		}
+		sectionCalls++
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer closeServer()
+
+	d := schema.TestResourceDataRaw(t, resourceNsxtFirewallSection().Schema, map[string]interface{}{})
+	d.SetId("section-1")
+	if err := resourceNsxtFirewallSectionRead(context.Background(), d, nsxtClients{NsxtClient: nsxClient}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sectionCalls != 0 {
+		t.Errorf("Expected applied_to to be read from single call, got %d additional calls", sectionCalls)
+	}
+	if d.Id() != "section-1" || d.Get("applied_to").(*schema.Set).Len() != 1 {
+		t.Errorf("Expected applied_to to be populated from first call, got %v", d.Get("applied_to"))
+	}
+}
@@ -49,6 +49,7 @@ func resourceNsxtIcmpTypeNsService() *schema.Resource {
 				Description:  "ICMP message code",
 				Optional:     true,
 				ValidateFunc: validation.IntBetween(0, 255),
+				RequiredWith: []string{"icmp_type"},
 			},
 			"icmp_type": {
 				Type:         schema.TypeInt,
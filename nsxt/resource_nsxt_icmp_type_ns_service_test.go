@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -75,6 +76,21 @@ func TestAccResourceNsxtIcmpTypeNsService_importBasic(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtIcmpTypeNsService_codeWithoutType(t *testing.T) {
+	serviceName := getAccTestResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXIcmpServiceNoTypeTemplate(serviceName),
+				ExpectError: regexp.MustCompile(`all of .icmp_code,icmp_type. must be specified`),
+			},
+		},
+	})
+}
+
 func testAccNSXIcmpServiceExists(displayName string, resourceName string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 
@@ -146,3 +162,12 @@ resource "nsxt_icmp_type_ns_service" "test" {
   }
 }`, serviceName, protocol, icmpType, icmpCode)
 }
+
+func testAccNSXIcmpServiceNoTypeTemplate(serviceName string) string {
+	return fmt.Sprintf(`
+resource "nsxt_icmp_type_ns_service" "test" {
+  display_name = "%s"
+  protocol     = "ICMPv4"
+  icmp_code    = "1"
+}`, serviceName)
+}
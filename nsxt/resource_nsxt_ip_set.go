@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vmware-nsxt/common"
 	"github.com/vmware/go-vmware-nsxt/manager"
 )
 
@@ -35,7 +36,8 @@ func resourceNsxtIPSet() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
-			"tag": getTagsSchema(),
+			"tag":       getTagsSchema(),
+			"tag_merge": getTagMergeSchema(),
 			"ip_addresses": {
 				Type:        schema.TypeSet,
 				Description: "Set of IP addresses",
@@ -104,7 +106,7 @@ func resourceNsxtIPSetRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("revision", ipSet.Revision)
 	d.Set("description", ipSet.Description)
 	d.Set("display_name", ipSet.DisplayName)
-	setTagsInSchema(d, ipSet.Tags)
+	setMergedTagsInSchema(d, ipSet.Tags)
 	d.Set("ip_addresses", ipSet.IpAddresses)
 
 	return nil
@@ -124,7 +126,13 @@ func resourceNsxtIPSetUpdate(d *schema.ResourceData, m interface{}) error {
 	revision := int64(d.Get("revision").(int))
 	description := d.Get("description").(string)
 	displayName := d.Get("display_name").(string)
-	tags := getTagsFromSchema(d)
+	tags, err := getMergedTagsFromSchema(d, func() ([]common.Tag, error) {
+		currIPSet, _, err := nsxClient.GroupingObjectsApi.ReadIPSet(nsxClient.Context, id)
+		return currIPSet.Tags, err
+	})
+	if err != nil {
+		return fmt.Errorf("Error during IpSet update: %v", err)
+	}
 	ipAddresses := interface2StringList(d.Get("ip_addresses").(*schema.Set).List())
 	ipSet := manager.IpSet{
 		Revision:    revision,
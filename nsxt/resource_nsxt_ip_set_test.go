@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/go-vmware-nsxt/common"
 )
 
 func TestAccResourceNsxtIpSet_basic(t *testing.T) {
@@ -106,6 +107,80 @@ func TestAccResourceNsxtIpSet_importBasic(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtIpSet_tagMerge(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_ip_set.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXIpSetCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXIpSetTagMergeTemplate(name, "tag1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXIpSetExists(name, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "tag_merge", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+					// Tag the IP set outside of terraform
+					testAccNSXIpSetAddTag(testResourceName, "external", "value"),
+				),
+			},
+			{
+				Config: testAccNSXIpSetTagMergeTemplate(name, "tag2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXIpSetExists(name, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(testResourceName, "tag.*", map[string]string{"scope": "scope1", "tag": "tag2"}),
+					testAccNSXIpSetTagCount(testResourceName, 2),
+				),
+			},
+		},
+	})
+}
+
+func testAccNSXIpSetAddTag(resourceName string, scope string, tag string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("IP Set resource %s not found in resources", resourceName)
+		}
+
+		ipSet, _, err := nsxClient.GroupingObjectsApi.ReadIPSet(nsxClient.Context, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error while retrieving IP Set ID %s. Error: %v", rs.Primary.ID, err)
+		}
+		ipSet.Tags = append(ipSet.Tags, common.Tag{Scope: scope, Tag: tag})
+		_, _, err = nsxClient.GroupingObjectsApi.UpdateIPSet(nsxClient.Context, rs.Primary.ID, ipSet)
+		if err != nil {
+			return fmt.Errorf("Error while updating IP Set ID %s. Error: %v", rs.Primary.ID, err)
+		}
+		return nil
+	}
+}
+
+func testAccNSXIpSetTagCount(resourceName string, expectedCount int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("IP Set resource %s not found in resources", resourceName)
+		}
+
+		ipSet, _, err := nsxClient.GroupingObjectsApi.ReadIPSet(nsxClient.Context, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error while retrieving IP Set ID %s. Error: %v", rs.Primary.ID, err)
+		}
+		if len(ipSet.Tags) != expectedCount {
+			return fmt.Errorf("Expected %d tags on IP Set %s, got %v", expectedCount, rs.Primary.ID, ipSet.Tags)
+		}
+		return nil
+	}
+}
+
 func testAccNSXIpSetExists(displayName string, resourceName string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 
@@ -194,3 +269,17 @@ resource "nsxt_ip_set" "test" {
   }
 }`, updatedName)
 }
+
+func testAccNSXIpSetTagMergeTemplate(name string, tag string) string {
+	return fmt.Sprintf(`
+resource "nsxt_ip_set" "test" {
+  display_name = "%s"
+  ip_addresses = ["1.1.1.1"]
+  tag_merge    = true
+
+  tag {
+    scope = "scope1"
+    tag   = "%s"
+  }
+}`, name, tag)
+}
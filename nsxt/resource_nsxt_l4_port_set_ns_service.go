@@ -4,9 +4,11 @@
 package nsxt
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,10 +19,15 @@ var protocolValues = []string{"TCP", "UDP"}
 
 func resourceNsxtL4PortSetNsService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNsxtL4PortSetNsServiceCreate,
-		Read:   resourceNsxtL4PortSetNsServiceRead,
-		Update: resourceNsxtL4PortSetNsServiceUpdate,
-		Delete: resourceNsxtL4PortSetNsServiceDelete,
+		CreateContext: withManagerDiagnostics(resourceNsxtL4PortSetNsServiceCreate),
+		ReadContext:   withManagerDiagnostics(resourceNsxtL4PortSetNsServiceRead),
+		UpdateContext: withManagerDiagnostics(resourceNsxtL4PortSetNsServiceUpdate),
+		DeleteContext: withManagerDiagnostics(resourceNsxtL4PortSetNsServiceDelete),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -72,12 +79,15 @@ func resourceNsxtL4PortSetNsService() *schema.Resource {
 	}
 }
 
-func resourceNsxtL4PortSetNsServiceCreate(d *schema.ResourceData, m interface{}) error {
+func resourceNsxtL4PortSetNsServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx, cancel := getOperationContext(ctx, nsxClient, d, schema.TimeoutCreate)
+	defer cancel()
+
 	description := d.Get("description").(string)
 	displayName := d.Get("display_name").(string)
 	tags := getTagsFromSchema(d)
@@ -99,7 +109,7 @@ func resourceNsxtL4PortSetNsServiceCreate(d *schema.ResourceData, m interface{})
 		},
 	}
 
-	nsService, resp, err := nsxClient.GroupingObjectsApi.CreateL4PortSetNSService(nsxClient.Context, nsService)
+	nsService, resp, err := nsxClient.GroupingObjectsApi.CreateL4PortSetNSService(ctx, nsService)
 
 	if err != nil {
 		return fmt.Errorf("Error during NsService create: %v", err)
@@ -109,21 +119,23 @@ func resourceNsxtL4PortSetNsServiceCreate(d *schema.ResourceData, m interface{})
 		return fmt.Errorf("Unexpected status returned during NsService create: %v", resp.StatusCode)
 	}
 	d.SetId(nsService.Id)
-	return resourceNsxtL4PortSetNsServiceRead(d, m)
+	return resourceNsxtL4PortSetNsServiceRead(ctx, d, m)
 }
 
-func resourceNsxtL4PortSetNsServiceRead(d *schema.ResourceData, m interface{}) error {
+func resourceNsxtL4PortSetNsServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx = getManagerContext(ctx, nsxClient)
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining ns service id")
 	}
 
-	nsService, resp, err := nsxClient.GroupingObjectsApi.ReadL4PortSetNSService(nsxClient.Context, id)
+	nsService, resp, err := nsxClient.GroupingObjectsApi.ReadL4PortSetNSService(ctx, id)
 	if resp != nil && resp.StatusCode == http.StatusNotFound {
 		log.Printf("[DEBUG] NsService %s not found", id)
 		d.SetId("")
@@ -147,17 +159,24 @@ func resourceNsxtL4PortSetNsServiceRead(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceNsxtL4PortSetNsServiceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceNsxtL4PortSetNsServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx, cancel := getOperationContext(ctx, nsxClient, d, schema.TimeoutUpdate)
+	defer cancel()
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining ns service id")
 	}
 
+	if d.Get("default_service").(bool) {
+		return fmt.Errorf("NsService %s is a default service and can not be modified", id)
+	}
+
 	description := d.Get("description").(string)
 	displayName := d.Get("display_name").(string)
 	tags := getTagsFromSchema(d)
@@ -181,28 +200,44 @@ func resourceNsxtL4PortSetNsServiceUpdate(d *schema.ResourceData, m interface{})
 		},
 	}
 
-	_, resp, err := nsxClient.GroupingObjectsApi.UpdateL4PortSetNSService(nsxClient.Context, id, nsService)
+	resp, err := retryUponConflict(m.(nsxtClients).CommonConfig.AutoRetryOnConflict,
+		func() (*http.Response, error) {
+			_, resp, err := nsxClient.GroupingObjectsApi.UpdateL4PortSetNSService(ctx, id, nsService)
+			return resp, err
+		},
+		func() error {
+			currService, _, err := nsxClient.GroupingObjectsApi.ReadL4PortSetNSService(ctx, id)
+			nsService.Revision = currService.Revision
+			return err
+		})
 	if err != nil || resp.StatusCode == http.StatusNotFound {
 		return fmt.Errorf("Error during NsService update: %v %v", err, resp)
 	}
 
-	return resourceNsxtL4PortSetNsServiceRead(d, m)
+	return resourceNsxtL4PortSetNsServiceRead(ctx, d, m)
 }
 
-func resourceNsxtL4PortSetNsServiceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceNsxtL4PortSetNsServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx, cancel := getOperationContext(ctx, nsxClient, d, schema.TimeoutDelete)
+	defer cancel()
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining ns service id")
 	}
 
+	if d.Get("default_service").(bool) {
+		return fmt.Errorf("NsService %s is a default service and can not be deleted", id)
+	}
+
 	localVarOptionals := make(map[string]interface{})
 	localVarOptionals["force"] = true
-	resp, err := nsxClient.GroupingObjectsApi.DeleteNSService(nsxClient.Context, id, localVarOptionals)
+	resp, err := nsxClient.GroupingObjectsApi.DeleteNSService(ctx, id, localVarOptionals)
 	if err != nil {
 		return fmt.Errorf("Error during NsService delete: %v", err)
 	}
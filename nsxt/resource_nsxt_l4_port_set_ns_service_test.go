@@ -5,11 +5,11 @@ package nsxt
 
 import (
 	"fmt"
-	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	api "github.com/vmware/go-vmware-nsxt"
 )
 
 func TestAccResourceNsxtL4PortNsService_basic(t *testing.T) {
@@ -72,58 +72,20 @@ func TestAccResourceNsxtL4PortNsService_importBasic(t *testing.T) {
 }
 
 func testAccNSXL4ServiceExists(displayName string, resourceName string) resource.TestCheckFunc {
-	return func(state *terraform.State) error {
-
-		nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
-
-		rs, ok := state.RootModule().Resources[resourceName]
-		if !ok {
-			return fmt.Errorf("NSX L4 NS service resource %s not found in resources", resourceName)
-		}
-
-		resourceID := rs.Primary.ID
-		if resourceID == "" {
-			return fmt.Errorf("NSX L4 NS service resource ID not set in resources ")
-		}
-
-		service, responseCode, err := nsxClient.GroupingObjectsApi.ReadL4PortSetNSService(nsxClient.Context, resourceID)
-		if err != nil {
-			return fmt.Errorf("Error while retrieving L4 NS service ID %s. Error: %v", resourceID, err)
-		}
-
-		if responseCode.StatusCode != http.StatusOK {
-			return fmt.Errorf("Error while checking if L4 NS service %s exists. HTTP return code was %d", resourceID, responseCode.StatusCode)
-		}
+	return resource.ComposeTestCheckFunc(
+		testAccNSXResourceExists(resourceName, displayName, testAccNSXL4ServicePresence),
+		resource.TestCheckResourceAttr(resourceName, "display_name", displayName),
+	)
+}
 
-		if displayName == service.DisplayName {
-			return nil
-		}
-		return fmt.Errorf("NSX L4 NS service %s wasn't found", displayName)
-	}
+func testAccNSXL4ServicePresence(nsxClient *api.APIClient, rs *terraform.ResourceState) (string, bool, error) {
+	service, resp, err := nsxClient.GroupingObjectsApi.ReadL4PortSetNSService(nsxClient.Context, rs.Primary.ID)
+	exists, err := testAccNSXResourcePresence(resp, err)
+	return service.DisplayName, exists, err
 }
 
 func testAccNSXL4ServiceCheckDestroy(state *terraform.State, displayName string) error {
-	nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
-	for _, rs := range state.RootModule().Resources {
-
-		if rs.Type != "nsxt_l4_port_set_ns_service" {
-			continue
-		}
-
-		resourceID := rs.Primary.Attributes["id"]
-		service, responseCode, err := nsxClient.GroupingObjectsApi.ReadL4PortSetNSService(nsxClient.Context, resourceID)
-		if err != nil {
-			if responseCode.StatusCode != http.StatusOK {
-				return nil
-			}
-			return fmt.Errorf("Error while retrieving L4 NS service ID %s. Error: %v", resourceID, err)
-		}
-
-		if displayName == service.DisplayName {
-			return fmt.Errorf("NSX L4 NS service %s still exists", displayName)
-		}
-	}
-	return nil
+	return testAccNSXResourceCheckDestroy(state, displayName, "nsxt_l4_port_set_ns_service", testAccNSXL4ServicePresence)
 }
 
 func testAccNSXserviceCreateTemplate(serviceName string, protocol string, port string) string {
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vmware-nsxt/loadbalancer"
 )
 
@@ -52,10 +53,11 @@ func resourceNsxtLbClientSslProfile() *schema.Resource {
 				Default:     true,
 			},
 			"session_cache_timeout": {
-				Type:        schema.TypeInt,
-				Description: "For how long the SSL session parameters can be reused",
-				Optional:    true,
-				Default:     300,
+				Type:         schema.TypeInt,
+				Description:  "For how long the SSL session parameters can be reused",
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntBetween(1, 86400),
 			},
 		},
 	}
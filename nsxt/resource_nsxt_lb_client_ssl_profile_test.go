@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -63,6 +64,29 @@ func TestAccResourceNsxtLbClientSSLProfile_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtLbClientSSLProfile_invalidValues(t *testing.T) {
+	name := getAccTestResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccOnlyLocalManager(t)
+			testAccTestMP(t)
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXLbClientSSLCreateTemplate(name, "TLS_V1_4"),
+				ExpectError: regexp.MustCompile(`TLS_V1_4`),
+			},
+			{
+				Config:      testAccNSXLbClientSSLSessionTimeoutTemplate(name, 0),
+				ExpectError: regexp.MustCompile(`expected session_cache_timeout to be in the range \(1 - 86400\)`),
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtLbClientSSLProfile_importBasic(t *testing.T) {
 	name := getAccTestResourceName()
 	testResourceName := "nsxt_lb_client_ssl_profile.test"
@@ -160,6 +184,15 @@ resource "nsxt_lb_client_ssl_profile" "test" {
 `, name, protocol)
 }
 
+func testAccNSXLbClientSSLSessionTimeoutTemplate(name string, timeout int) string {
+	return fmt.Sprintf(`
+resource "nsxt_lb_client_ssl_profile" "test" {
+  display_name          = "%s"
+  session_cache_timeout = %d
+}
+`, name, timeout)
+}
+
 func testAccNSXLbClientSSLCreateTemplateTrivial() string {
 	return `
 resource "nsxt_lb_client_ssl_profile" "test" {
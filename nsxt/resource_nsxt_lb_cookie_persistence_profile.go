@@ -114,6 +114,31 @@ func resourceNsxtLbCookiePersistenceProfile() *schema.Resource {
 	}
 }
 
+// validateInsertParams verifies cookie expiration settings are complete,
+// since the documented requirements are not enforced by the schema
+func validateInsertParams(d *schema.ResourceData) error {
+	if d.Get("cookie_mode").(string) != "INSERT" {
+		return nil
+	}
+	for _, conf := range d.Get("insert_mode_params").([]interface{}) {
+		data, ok := conf.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		expiryType := data["cookie_expiry_type"].(string)
+		if expiryType == "" {
+			continue
+		}
+		if data["max_idle_time"].(int) <= 0 {
+			return fmt.Errorf("max_idle_time is required when cookie_expiry_type is %s", expiryType)
+		}
+		if expiryType == "SESSION_COOKIE_TIME" && data["max_life_time"].(int) <= 0 {
+			return fmt.Errorf("max_life_time is required when cookie_expiry_type is %s", expiryType)
+		}
+	}
+	return nil
+}
+
 func getInsertParamsFromSchema(d *schema.ResourceData) (string, string, *loadbalancer.LbCookieTime) {
 	confs := d.Get("insert_mode_params").([]interface{})
 	cookieMode := d.Get("cookie_mode").(string)
@@ -182,6 +207,9 @@ func resourceNsxtLbCookiePersistenceProfileCreate(d *schema.ResourceData, m inte
 	cookieGarble := d.Get("cookie_garble").(bool)
 	cookieMode := d.Get("cookie_mode").(string)
 	cookieName := d.Get("cookie_name").(string)
+	if err := validateInsertParams(d); err != nil {
+		return err
+	}
 	cookieDomain, cookiePath, cookieTime := getInsertParamsFromSchema(d)
 	lbCookiePersistenceProfile := loadbalancer.LbCookiePersistenceProfile{
 		Description:       description,
@@ -266,6 +294,9 @@ func resourceNsxtLbCookiePersistenceProfileUpdate(d *schema.ResourceData, m inte
 	cookieGarble := d.Get("cookie_garble").(bool)
 	cookieMode := d.Get("cookie_mode").(string)
 	cookieName := d.Get("cookie_name").(string)
+	if err := validateInsertParams(d); err != nil {
+		return err
+	}
 	cookieDomain, cookiePath, cookieTime := getInsertParamsFromSchema(d)
 	lbCookiePersistenceProfile := loadbalancer.LbCookiePersistenceProfile{
 		Revision:          revision,
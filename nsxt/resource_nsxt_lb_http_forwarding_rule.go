@@ -213,7 +213,7 @@ func setLbRuleHTTPForwardingConditionsInSchema(d *schema.ResourceData, condition
 			elem["value"] = condition.HeaderValue
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			headerConditionList = append(headerConditionList, elem)
 		}
 
@@ -222,7 +222,7 @@ func setLbRuleHTTPForwardingConditionsInSchema(d *schema.ResourceData, condition
 			elem["value"] = condition.CookieValue
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			cookieConditionList = append(cookieConditionList, elem)
 		}
 
@@ -230,7 +230,7 @@ func setLbRuleHTTPForwardingConditionsInSchema(d *schema.ResourceData, condition
 			elem["value"] = condition.BodyValue
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			bodyConditionList = append(bodyConditionList, elem)
 		}
 
@@ -250,7 +250,7 @@ func setLbRuleHTTPForwardingConditionsInSchema(d *schema.ResourceData, condition
 			elem["uri"] = condition.Uri
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			uriConditionList = append(uriConditionList, elem)
 		}
 
@@ -175,7 +175,7 @@ func setLbRuleHTTPRequestConditionsInSchema(d *schema.ResourceData, conditions [
 			elem["value"] = condition.HeaderValue
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			headerConditionList = append(headerConditionList, elem)
 		}
 
@@ -184,7 +184,7 @@ func setLbRuleHTTPRequestConditionsInSchema(d *schema.ResourceData, conditions [
 			elem["value"] = condition.CookieValue
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			cookieConditionList = append(cookieConditionList, elem)
 		}
 
@@ -192,7 +192,7 @@ func setLbRuleHTTPRequestConditionsInSchema(d *schema.ResourceData, conditions [
 			elem["value"] = condition.BodyValue
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			bodyConditionList = append(bodyConditionList, elem)
 		}
 
@@ -212,7 +212,7 @@ func setLbRuleHTTPRequestConditionsInSchema(d *schema.ResourceData, conditions [
 			elem["uri"] = condition.Uri
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			uriConditionList = append(uriConditionList, elem)
 		}
 
@@ -220,7 +220,7 @@ func setLbRuleHTTPRequestConditionsInSchema(d *schema.ResourceData, conditions [
 			elem["uri_arguments"] = condition.UriArguments
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			uriArgumentsConditionList = append(uriArgumentsConditionList, elem)
 		}
 
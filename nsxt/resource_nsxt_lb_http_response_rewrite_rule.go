@@ -199,7 +199,7 @@ func setLbRuleHTTPResponseConditionsInSchema(d *schema.ResourceData, conditions
 			elem["uri"] = condition.Uri
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			uriConditionList = append(uriConditionList, elem)
 		}
 
@@ -207,7 +207,7 @@ func setLbRuleHTTPResponseConditionsInSchema(d *schema.ResourceData, conditions
 			elem["uri_arguments"] = condition.UriArguments
 			elem["inverse"] = condition.Inverse
 			elem["match_type"] = condition.MatchType
-			elem["case_sensitive"] = *condition.CaseSensitive
+			elem["case_sensitive"] = getLbRuleCaseSensitive(condition)
 			uriArgumentsConditionList = append(uriArgumentsConditionList, elem)
 		}
 
@@ -368,7 +368,9 @@ func resourceNsxtLbHTTPVirtualServerRead(d *schema.ResourceData, m interface{})
 	d.Set("max_new_connection_rate", lbVirtualServer.MaxNewConnectionRate)
 	d.Set("persistence_profile_id", lbVirtualServer.PersistenceProfileId)
 	d.Set("pool_id", lbVirtualServer.PoolId)
-	d.Set("port", lbVirtualServer.Ports[0])
+	if len(lbVirtualServer.Ports) > 0 {
+		d.Set("port", lbVirtualServer.Ports[0])
+	}
 	d.Set("rule_ids", lbVirtualServer.RuleIds)
 	setServerSSLBindingInSchema(d, lbVirtualServer.ServerSslProfileBinding)
 	d.Set("sorry_pool_id", lbVirtualServer.SorryPoolId)
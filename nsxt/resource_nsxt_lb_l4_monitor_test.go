@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -28,6 +29,25 @@ func TestAccResourceNsxtLbUdpMonitor_importBasic(t *testing.T) {
 	testAccResourceNsxtLbL4MonitorImport(t, "udp")
 }
 
+func TestAccResourceNsxtLbTcpMonitor_invalidInterval(t *testing.T) {
+	name := getAccTestResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccOnlyLocalManager(t)
+			testAccTestMP(t)
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXLbL4MonitorCreateTemplate("tcp", name, "2", "0", "7887", "12", "Client hello", "Server hello"),
+				ExpectError: regexp.MustCompile(`expected interval to be at least \(1\)`),
+			},
+		},
+	})
+}
+
 func testAccResourceNsxtLbL4MonitorBasic(t *testing.T, protocol string) {
 	name := getAccTestResourceName()
 	updatedName := getAccTestResourceName()
@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -88,6 +89,27 @@ func testAccResourceNsxtLbL4VirtualServer(t *testing.T, protocol string) {
 	})
 }
 
+func TestAccResourceNsxtLbTCPVirtualServer_invalidValues(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccOnlyLocalManager(t)
+			testAccTestMP(t)
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXLbL4VirtualServerInvalidTemplate("1.1.1.1", "90-80"),
+				ExpectError: regexp.MustCompile(`to be a port range or a single port`),
+			},
+			{
+				Config:      testAccNSXLbL4VirtualServerInvalidTemplate("1.1.1", "80"),
+				ExpectError: regexp.MustCompile(`to contain a valid IP`),
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtLbTCPVirtualServer_importBasic(t *testing.T) {
 	testAccResourceNsxtLbL4VirtualServerImport(t, "tcp")
 }
@@ -229,3 +251,17 @@ resource "nsxt_lb_%s_virtual_server" "test" {
 }
 `, protocol, protocol, protocol)
 }
+
+func testAccNSXLbL4VirtualServerInvalidTemplate(ipAddress string, port string) string {
+	return fmt.Sprintf(`
+resource "nsxt_lb_fast_tcp_application_profile" "test" {
+  display_name = "lb virtual server test"
+}
+
+resource "nsxt_lb_tcp_virtual_server" "test" {
+  application_profile_id = "${nsxt_lb_fast_tcp_application_profile.test.id}"
+  ip_address             = "%s"
+  ports                  = ["%s"]
+}
+`, ipAddress, port)
+}
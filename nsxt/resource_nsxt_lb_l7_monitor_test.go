@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -32,6 +33,29 @@ func TestAccResourceNsxtLbHTTPSMonitor_importBasic(t *testing.T) {
 	testAccResourceNsxtLbL7MonitorImport(t, "https")
 }
 
+func TestAccResourceNsxtLbHTTPMonitor_invalidValues(t *testing.T) {
+	name := getAccTestResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccOnlyLocalManager(t)
+			testAccTestMP(t)
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXLbL7MonitorCreateTemplate(name, "http", "CONNECT", "", ""),
+				ExpectError: regexp.MustCompile(`expected request_method to be one of`),
+			},
+			{
+				Config:      testAccNSXLbHTTPMonitorStatusCodeTemplate(name, 600),
+				ExpectError: regexp.MustCompile(`to be in the range \(100 - 599\)`),
+			},
+		},
+	})
+}
+
 func testAccResourceNsxtLbL7MonitorBasic(t *testing.T, protocol string) {
 	name := getAccTestResourceName()
 	testResourceName := fmt.Sprintf("nsxt_lb_%s_monitor.test", protocol)
@@ -231,6 +255,15 @@ resource "nsxt_lb_%s_monitor" "test" {
 `, protocol, name, requestMethod, requestBody, responseBody)
 }
 
+func testAccNSXLbHTTPMonitorStatusCodeTemplate(name string, statusCode int) string {
+	return fmt.Sprintf(`
+resource "nsxt_lb_http_monitor" "test" {
+  display_name          = "%s"
+  response_status_codes = [200, %d]
+}
+`, name, statusCode)
+}
+
 func testAccNSXLbL7MonitorCreateTemplateTrivial(protocol string) string {
 	return fmt.Sprintf(`
 resource "nsxt_lb_%s_monitor" "test" {
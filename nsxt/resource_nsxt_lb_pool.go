@@ -283,6 +283,40 @@ func setPoolMembersInSchema(d *schema.ResourceData, members []loadbalancer.PoolM
 	return err
 }
 
+// orderPoolMembersByState aligns pool members returned by NSX with the order
+// of members in current state, since member order is not meaningful for NSX
+// and might change between reads. Members are matched by ip address and port,
+// members unknown to state are appended at the end.
+func orderPoolMembersByState(stateMembers []interface{}, members []loadbalancer.PoolMember) []loadbalancer.PoolMember {
+	if len(stateMembers) == 0 || len(members) == 0 {
+		return members
+	}
+
+	used := make([]bool, len(members))
+	orderedMembers := make([]loadbalancer.PoolMember, 0, len(members))
+	for _, stateMember := range stateMembers {
+		data, ok := stateMember.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ipAddress, _ := data["ip_address"].(string)
+		port, _ := data["port"].(string)
+		for i, member := range members {
+			if !used[i] && member.IpAddress == ipAddress && member.Port == port {
+				used[i] = true
+				orderedMembers = append(orderedMembers, member)
+				break
+			}
+		}
+	}
+	for i, member := range members {
+		if !used[i] {
+			orderedMembers = append(orderedMembers, member)
+		}
+	}
+	return orderedMembers
+}
+
 func getPoolMembersFromSchema(d *schema.ResourceData) []loadbalancer.PoolMember {
 	members := d.Get("member").([]interface{})
 	var memberList []loadbalancer.PoolMember
@@ -428,7 +462,8 @@ func resourceNsxtLbPoolRead(d *schema.ResourceData, m interface{}) error {
 	}
 	d.Set("passive_monitor_id", lbPool.PassiveMonitorId)
 	d.Set("algorithm", lbPool.Algorithm)
-	err = setPoolMembersInSchema(d, lbPool.Members)
+	members := orderPoolMembersByState(d.Get("member").([]interface{}), lbPool.Members)
+	err = setPoolMembersInSchema(d, members)
 	if err != nil {
 		return fmt.Errorf("Error during LB Pool members set in schema: %v", err)
 	}
@@ -10,8 +10,34 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/go-vmware-nsxt/loadbalancer"
 )
 
+func TestOrderPoolMembersByState(t *testing.T) {
+	stateMembers := []interface{}{
+		map[string]interface{}{"ip_address": "1.1.1.2", "port": "80"},
+		map[string]interface{}{"ip_address": "1.1.1.1", "port": "80"},
+		map[string]interface{}{"ip_address": "1.1.1.1", "port": "443"},
+	}
+	members := []loadbalancer.PoolMember{
+		{IpAddress: "1.1.1.1", Port: "443"},
+		{IpAddress: "1.1.1.3", Port: "80"},
+		{IpAddress: "1.1.1.1", Port: "80"},
+		{IpAddress: "1.1.1.2", Port: "80"},
+	}
+
+	ordered := orderPoolMembersByState(stateMembers, members)
+	expected := []string{"1.1.1.2:80", "1.1.1.1:80", "1.1.1.1:443", "1.1.1.3:80"}
+	if len(ordered) != len(expected) {
+		t.Fatalf("Expected %d members, got %d", len(expected), len(ordered))
+	}
+	for i, member := range ordered {
+		if member.IpAddress+":"+member.Port != expected[i] {
+			t.Errorf("Expected member %d to be %s, got %s:%s", i, expected[i], member.IpAddress, member.Port)
+		}
+	}
+}
+
 func TestAccResourceNsxtLbPool_basic(t *testing.T) {
 	name := getAccTestResourceName()
 	updatedName := getAccTestResourceName()
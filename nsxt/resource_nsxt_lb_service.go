@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/vmware/go-vmware-nsxt"
 	"github.com/vmware/go-vmware-nsxt/loadbalancer"
 )
 
@@ -80,6 +81,25 @@ func resourceNsxtLbService() *schema.Resource {
 	}
 }
 
+// validateLbServiceRouter verifies the attachment points to a Tier1 logical
+// router with edge cluster, since NSX errors out otherwise
+func validateLbServiceRouter(nsxClient *api.APIClient, logicalRouterID string) error {
+	logicalRouter, resp, err := nsxClient.LogicalRoutingAndServicesApi.ReadLogicalRouter(nsxClient.Context, logicalRouterID)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("Logical router %s for LbService was not found", logicalRouterID)
+	}
+	if err != nil {
+		return fmt.Errorf("Error while reading logical router %s for LbService: %v", logicalRouterID, err)
+	}
+	if logicalRouter.RouterType != "TIER1" {
+		return fmt.Errorf("LbService can only be attached to TIER1 logical router, router %s is %s", logicalRouterID, logicalRouter.RouterType)
+	}
+	if logicalRouter.EdgeClusterId == "" {
+		return fmt.Errorf("LbService can only be attached to logical router with edge cluster, router %s has none", logicalRouterID)
+	}
+	return nil
+}
+
 func resourceNsxtLbServiceCreate(d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
@@ -94,6 +114,9 @@ func resourceNsxtLbServiceCreate(d *schema.ResourceData, m interface{}) error {
 	errorLogLevel := d.Get("error_log_level").(string)
 	size := d.Get("size").(string)
 	virtualServerIds := getStringListFromSchemaSet(d, "virtual_server_ids")
+	if err := validateLbServiceRouter(nsxClient, logicalRouterID); err != nil {
+		return err
+	}
 
 	lbService := loadbalancer.LbService{
 		Description:      description,
@@ -179,6 +202,11 @@ func resourceNsxtLbServiceUpdate(d *schema.ResourceData, m interface{}) error {
 	errorLogLevel := d.Get("error_log_level").(string)
 	size := d.Get("size").(string)
 	virtualServerIds := getStringListFromSchemaSet(d, "virtual_server_ids")
+	if d.HasChange("logical_router_id") {
+		if err := validateLbServiceRouter(nsxClient, logicalRouterID); err != nil {
+			return err
+		}
+	}
 	lbService := loadbalancer.LbService{
 		Revision:         revision,
 		Description:      description,
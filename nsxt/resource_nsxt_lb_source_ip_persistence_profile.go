@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vmware-nsxt/loadbalancer"
 )
 
@@ -54,10 +55,11 @@ func resourceNsxtLbSourceIPPersistenceProfile() *schema.Resource {
 				Default:     true,
 			},
 			"timeout": {
-				Type:        schema.TypeInt,
-				Description: "Persistence expiration time in seconds, counted from the time all the connections are completed",
-				Optional:    true,
-				Default:     300,
+				Type:         schema.TypeInt,
+				Description:  "Persistence expiration time in seconds, counted from the time all the connections are completed",
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 		},
 	}
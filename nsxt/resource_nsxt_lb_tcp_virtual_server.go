@@ -55,9 +55,10 @@ func resourceNsxtLbTCPVirtualServer() *schema.Resource {
 				Default:     true,
 			},
 			"ip_address": {
-				Type:        schema.TypeString,
-				Description: "virtual server IP address",
-				Required:    true,
+				Type:         schema.TypeString,
+				Description:  "virtual server IP address",
+				Required:     true,
+				ValidateFunc: validateSingleIP(),
 			},
 			"ports": {
 				Type:        schema.TypeList,
@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -182,6 +183,29 @@ func setDhcpGenericOptionsInSchema(d *schema.ResourceData, opts []manager.Generi
 	return err
 }
 
+// validateDhcpServerGateway verifies the gateway is reachable on the DHCP
+// server subnet and does not collide with the server address
+func validateDhcpServerGateway(serverIP string, gatewayIP string) error {
+	if gatewayIP == "" {
+		return nil
+	}
+	ip, ipNet, err := net.ParseCIDR(serverIP)
+	if err != nil {
+		return fmt.Errorf("dhcp_server_ip %s is not a valid CIDR: %v", serverIP, err)
+	}
+	gateway := net.ParseIP(gatewayIP)
+	if gateway == nil {
+		return fmt.Errorf("gateway_ip %s is not a valid IP", gatewayIP)
+	}
+	if !ipNet.Contains(gateway) {
+		return fmt.Errorf("gateway_ip %s is not in the dhcp_server_ip subnet %s", gatewayIP, ipNet.String())
+	}
+	if ip.Equal(gateway) {
+		return fmt.Errorf("gateway_ip %s can not be the same as the DHCP server IP", gatewayIP)
+	}
+	return nil
+}
+
 func resourceNsxtLogicalDhcpServerCreate(d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
@@ -198,11 +222,16 @@ func resourceNsxtLogicalDhcpServerCreate(d *schema.ResourceData, m interface{})
 			StaticRoutes: opt121Routes,
 		}
 	}
+	dhcpServerIP := d.Get("dhcp_server_ip").(string)
+	gatewayIP := d.Get("gateway_ip").(string)
+	if err := validateDhcpServerGateway(dhcpServerIP, gatewayIP); err != nil {
+		return err
+	}
 	ipv4DhcpServer := manager.IPv4DhcpServer{
-		DhcpServerIp:   d.Get("dhcp_server_ip").(string),
+		DhcpServerIp:   dhcpServerIP,
 		DnsNameservers: interface2StringList(d.Get("dns_name_servers").([]interface{})),
 		DomainName:     d.Get("domain_name").(string),
-		GatewayIp:      d.Get("gateway_ip").(string),
+		GatewayIp:      gatewayIP,
 		Options: &manager.DhcpOptions{
 			Option121: opt121,
 			Others:    getDhcpGenericOptions(d),
@@ -307,11 +336,16 @@ func resourceNsxtLogicalDhcpServerUpdate(d *schema.ResourceData, m interface{})
 			StaticRoutes: opt121Routes,
 		}
 	}
+	dhcpServerIP := d.Get("dhcp_server_ip").(string)
+	gatewayIP := d.Get("gateway_ip").(string)
+	if err := validateDhcpServerGateway(dhcpServerIP, gatewayIP); err != nil {
+		return err
+	}
 	ipv4DhcpServer := manager.IPv4DhcpServer{
-		DhcpServerIp:   d.Get("dhcp_server_ip").(string),
+		DhcpServerIp:   dhcpServerIP,
 		DnsNameservers: interface2StringList(d.Get("dns_name_servers").([]interface{})),
 		DomainName:     d.Get("domain_name").(string),
-		GatewayIp:      d.Get("gateway_ip").(string),
+		GatewayIp:      gatewayIP,
 		Options: &manager.DhcpOptions{
 			Option121: opt121,
 			Others:    getDhcpGenericOptions(d),
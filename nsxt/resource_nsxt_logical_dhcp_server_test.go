@@ -320,3 +320,28 @@ resource "nsxt_logical_dhcp_server" "test" {
   }
 }`, updatedName, ip1, ip2, ip3, ip4)
 }
+
+func TestValidateDhcpServerGateway(t *testing.T) {
+	cases := []struct {
+		serverIP  string
+		gatewayIP string
+		valid     bool
+	}{
+		{"1.1.1.10/24", "", true},
+		{"1.1.1.10/24", "1.1.1.20", true},
+		{"1.1.1.10/24", "1.1.2.20", false},
+		{"1.1.1.10/24", "1.1.1.10", false},
+		{"1.1.1.10/30", "1.1.1.11", true},
+		{"1.1.1.10/30", "1.1.1.12", false},
+	}
+
+	for _, c := range cases {
+		err := validateDhcpServerGateway(c.serverIP, c.gatewayIP)
+		if c.valid && err != nil {
+			t.Errorf("Expected %+v to be valid, got error: %v", c, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
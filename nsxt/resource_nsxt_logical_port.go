@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vmware-nsxt/common"
 	"github.com/vmware/go-vmware-nsxt/manager"
 )
 
@@ -44,10 +45,56 @@ func resourceNsxtLogicalPort() *schema.Resource {
 			"admin_state":          getAdminStateSchema(),
 			"switching_profile_id": getSwitchingProfileIdsSchema(),
 			"tag":                  getTagsSchema(),
+			"tag_merge":            getTagMergeSchema(),
+			"attachment": {
+				Type:        schema.TypeList,
+				Description: "Attachment of the logical port. Attachment done outside of terraform is reflected here",
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Description: "Type of the attachment, VIF by default",
+							Optional:    true,
+							Computed:    true,
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Description: "Identifier of the interface attached to the logical port",
+							Required:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+func getLogicalPortAttachmentFromSchema(d *schema.ResourceData) *manager.LogicalPortAttachment {
+	attachments := d.Get("attachment").([]interface{})
+	if len(attachments) == 0 || attachments[0] == nil {
+		return nil
+	}
+	data := attachments[0].(map[string]interface{})
+	return &manager.LogicalPortAttachment{
+		AttachmentType: data["type"].(string),
+		Id:             data["id"].(string),
+	}
+}
+
+func setLogicalPortAttachmentInSchema(d *schema.ResourceData, attachment *manager.LogicalPortAttachment) error {
+	var attachmentList []map[string]interface{}
+	if attachment != nil {
+		elem := make(map[string]interface{})
+		elem["type"] = attachment.AttachmentType
+		elem["id"] = attachment.Id
+		attachmentList = append(attachmentList, elem)
+	}
+	return d.Set("attachment", attachmentList)
+}
+
 func resourceNsxtLogicalPortCreate(d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
@@ -67,6 +114,7 @@ func resourceNsxtLogicalPortCreate(d *schema.ResourceData, m interface{}) error
 		LogicalSwitchId:     lsID,
 		AdminState:          adminState,
 		SwitchingProfileIds: profilesList,
+		Attachment:          getLogicalPortAttachmentFromSchema(d),
 		Tags:                tagList}
 
 	lp, resp, err := nsxClient.LogicalSwitchingApi.CreateLogicalPort(nsxClient.Context, lp)
@@ -113,7 +161,11 @@ func resourceNsxtLogicalPortRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Error during logical port switching profiles set in schema: %v", err)
 	}
-	setTagsInSchema(d, logicalPort.Tags)
+	setMergedTagsInSchema(d, logicalPort.Tags)
+	err = setLogicalPortAttachmentInSchema(d, logicalPort.Attachment)
+	if err != nil {
+		return fmt.Errorf("Error during logical port attachment set in schema: %v", err)
+	}
 
 	return nil
 }
@@ -129,14 +181,11 @@ func resourceNsxtLogicalPortUpdate(d *schema.ResourceData, m interface{}) error
 	description := d.Get("description").(string)
 	adminState := d.Get("admin_state").(string)
 	profilesList := getSwitchingProfileIdsFromSchema(d)
-	tagList := getTagsFromSchema(d)
 	revision := int64(d.Get("revision").(int))
 
-	// Some of the port attributes (attachment) are not exposed to terraform.
-	// If we try to update port based on terraform attributes only, apply will fail
-	// due to missing info.
-	// We don't expose attachment to terraform, since it will become out of sync
-	// once attachment info is updated/remove outside the scope of port management.
+	// Attachment may be updated outside of terraform, and carries context that
+	// is not exposed to terraform. Hence port is updated based on its current
+	// state, and attachment is only overridden when changed in configuration.
 
 	lp, resp, err := nsxClient.LogicalSwitchingApi.GetLogicalPort(nsxClient.Context, id)
 	if resp.StatusCode == http.StatusNotFound {
@@ -146,12 +195,20 @@ func resourceNsxtLogicalPortUpdate(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("Error while reading logical port %s: %v", id, err)
 	}
 
+	// Port was just retrieved, hence current tags are at hand
+	tagList, _ := getMergedTagsFromSchema(d, func() ([]common.Tag, error) {
+		return lp.Tags, nil
+	})
+
 	lp.DisplayName = name
 	lp.Description = description
 	lp.AdminState = adminState
 	lp.SwitchingProfileIds = profilesList
 	lp.Tags = tagList
 	lp.Revision = revision
+	if d.HasChange("attachment") {
+		lp.Attachment = getLogicalPortAttachmentFromSchema(d)
+	}
 
 	lp, resp, err = nsxClient.LogicalSwitchingApi.UpdateLogicalPort(nsxClient.Context, id, lp)
 	if err != nil || resp.StatusCode == http.StatusNotFound {
@@ -49,6 +49,42 @@ func TestAccResourceNsxtLogicalPort_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtLogicalPort_withAttachment(t *testing.T) {
+	portName := getAccTestResourceName()
+	testResourceName := "nsxt_logical_port.test"
+	transportZoneName := getOverlayTransportZoneName()
+	attachmentID := newUUID()
+	updatedAttachmentID := newUUID()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXLogicalPortCheckDestroy(state, portName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXLogicalPortWithAttachmentTemplate(portName, transportZoneName, attachmentID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXLogicalPortExists(portName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "attachment.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "attachment.0.type", "VIF"),
+					resource.TestCheckResourceAttr(testResourceName, "attachment.0.id", attachmentID),
+				),
+			},
+			{
+				Config: testAccNSXLogicalPortWithAttachmentTemplate(portName, transportZoneName, updatedAttachmentID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXLogicalPortExists(portName, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "attachment.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "attachment.0.type", "VIF"),
+					resource.TestCheckResourceAttr(testResourceName, "attachment.0.id", updatedAttachmentID),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtLogicalPort_withProfiles(t *testing.T) {
 	portName := getAccTestResourceName()
 	updatePortName := getAccTestResourceName()
@@ -237,6 +273,19 @@ resource "nsxt_logical_port" "test" {
 }`, portName)
 }
 
+func testAccNSXLogicalPortWithAttachmentTemplate(portName string, transportZoneName string, attachmentID string) string {
+	return testAccNSXLogicalSwitchCreateForPort(transportZoneName) + fmt.Sprintf(`
+resource "nsxt_logical_port" "test" {
+  display_name      = "%s"
+  logical_switch_id = nsxt_logical_switch.test.id
+
+  attachment {
+    type = "VIF"
+    id   = "%s"
+  }
+}`, portName, attachmentID)
+}
+
 func testAccNSXLogicalPortUpdateTemplate(portUpdatedName string, transportZoneName string) string {
 	return testAccNSXLogicalSwitchCreateForPort(transportZoneName) + fmt.Sprintf(`
 resource "nsxt_logical_port" "test" {
@@ -98,6 +98,26 @@ func resourceNsxtLogicalSwitch() *schema.Resource {
 	}
 }
 
+// validateLogicalSwitchTransportZone verifies that vlan is only set for VLAN
+// transport zones, and non-default replication mode only for overlay ones
+func validateLogicalSwitchTransportZone(nsxClient *api.APIClient, transportZoneID string, vlan int64, replicationMode string) error {
+	transportZone, resp, err := nsxClient.NetworkTransportApi.GetTransportZone(nsxClient.Context, transportZoneID)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("Transport zone %s for LogicalSwitch was not found", transportZoneID)
+	}
+	if err != nil {
+		return fmt.Errorf("Error while reading transport zone %s for LogicalSwitch: %v", transportZoneID, err)
+	}
+	if transportZone.TransportType == "OVERLAY" && vlan != 0 {
+		return fmt.Errorf("vlan can only be set for VLAN transport zones, transport zone %s is OVERLAY", transportZoneID)
+	}
+	// MTEP is the default replication mode, and is not validated
+	if transportZone.TransportType == "VLAN" && replicationMode != "" && replicationMode != "MTEP" {
+		return fmt.Errorf("replication_mode can only be set for OVERLAY transport zones, transport zone %s is VLAN", transportZoneID)
+	}
+	return nil
+}
+
 func resourceNsxtLogicalSwitchCreate(d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
@@ -117,6 +137,11 @@ func resourceNsxtLogicalSwitchCreate(d *schema.ResourceData, m interface{}) erro
 	vlan := int64(d.Get("vlan").(int))
 	vni := int32(d.Get("vni").(int))
 
+	err := validateLogicalSwitchTransportZone(nsxClient, transportZoneID, vlan, replicationMode)
+	if err != nil {
+		return err
+	}
+
 	logicalSwitch := manager.LogicalSwitch{
 		Description:         description,
 		DisplayName:         displayName,
@@ -271,6 +296,14 @@ func resourceNsxtLogicalSwitchUpdate(d *schema.ResourceData, m interface{}) erro
 	vlan := int64(d.Get("vlan").(int))
 	vni := int32(d.Get("vni").(int))
 	revision := int64(d.Get("revision").(int))
+
+	if d.HasChange("vlan") || d.HasChange("replication_mode") {
+		err := validateLogicalSwitchTransportZone(nsxClient, transportZoneID, vlan, replicationMode)
+		if err != nil {
+			return err
+		}
+	}
+
 	logicalSwitch := manager.LogicalSwitch{
 		Description:         description,
 		DisplayName:         displayName,
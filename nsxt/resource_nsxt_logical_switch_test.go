@@ -33,6 +33,10 @@ func TestAccResourceNsxtLogicalSwitch_basic(t *testing.T) {
 				Config:      testAccNSXLogicalSwitchNoTZIDTemplate(switchName),
 				ExpectError: regexp.MustCompile(`Missing required argument`),
 			},
+			{
+				Config:      testAccNSXLogicalSwitchCreateTemplate(resourceName, switchName, transportZoneName, "1", replicationMode),
+				ExpectError: regexp.MustCompile(`vlan can only be set for VLAN transport zones`),
+			},
 			{
 				Config: testAccNSXLogicalSwitchCreateTemplate(resourceName, switchName, transportZoneName, novlan, replicationMode),
 				Check: resource.ComposeTestCheckFunc(
@@ -81,6 +85,10 @@ func TestAccResourceNsxtLogicalSwitch_vlan(t *testing.T) {
 			return testAccNSXLogicalSwitchCheckDestroy(state, updateSwitchName, "nsxt_logical_switch")
 		},
 		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXLogicalSwitchCreateTemplate(resourceName, switchName, transportZoneName, origvlan, "SOURCE"),
+				ExpectError: regexp.MustCompile(`replication_mode can only be set for OVERLAY transport zones`),
+			},
 			{
 				Config: testAccNSXLogicalSwitchCreateTemplate(resourceName, switchName, transportZoneName, origvlan, replicationMode),
 				Check: resource.ComposeTestCheckFunc(
@@ -114,7 +114,7 @@ func resourceNsxtLogicalTier1Router() *schema.Resource {
 
 func resourceNsxtLogicalTier1RouterReadAdv(d *schema.ResourceData, nsxClient *api.APIClient, id string) error {
 	advConfig, resp, err := nsxClient.LogicalRoutingAndServicesApi.ReadAdvertisementConfig(nsxClient.Context, id)
-	if resp.StatusCode == http.StatusNotFound {
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
 		return fmt.Errorf("LogicalTier1Router %s Advertisement config not found", id)
 	}
 	if err != nil {
@@ -132,24 +132,28 @@ func resourceNsxtLogicalTier1RouterReadAdv(d *schema.ResourceData, nsxClient *ap
 
 func resourceNsxtLogicalTier1RouterCreateAdv(d *schema.ResourceData, nsxClient *api.APIClient, id string) error {
 	enableRouterAdvertisement := d.Get("enable_router_advertisement").(bool)
-	if enableRouterAdvertisement {
-		advConnected := d.Get("advertise_connected_routes").(bool)
-		advStatic := d.Get("advertise_static_routes").(bool)
-		advNat := d.Get("advertise_nat_routes").(bool)
-		advLbVip := d.Get("advertise_lb_vip_routes").(bool)
-		advLbSnatIP := d.Get("advertise_lb_snat_ip_routes").(bool)
-		advConfig := manager.AdvertisementConfig{
-			Enabled:                     true,
-			AdvertiseNsxConnectedRoutes: advConnected,
-			AdvertiseStaticRoutes:       advStatic,
-			AdvertiseNatRoutes:          advNat,
-			AdvertiseLbVip:              advLbVip,
-			AdvertiseLbSnatIp:           advLbSnatIP,
-		}
-		_, _, err := nsxClient.LogicalRoutingAndServicesApi.UpdateAdvertisementConfig(nsxClient.Context, id, advConfig)
-		return err
+	advConnected := d.Get("advertise_connected_routes").(bool)
+	advStatic := d.Get("advertise_static_routes").(bool)
+	advNat := d.Get("advertise_nat_routes").(bool)
+	advLbVip := d.Get("advertise_lb_vip_routes").(bool)
+	advLbSnatIP := d.Get("advertise_lb_snat_ip_routes").(bool)
+	if !enableRouterAdvertisement && !advConnected && !advStatic && !advNat && !advLbVip && !advLbSnatIP {
+		// The router is created with advertisement config in this state,
+		// no need for the second API call
+		return nil
 	}
-	return nil
+	// Advertisement flags are applied even when advertisement is disabled,
+	// so that they take effect once it is enabled and do not cause a diff
+	advConfig := manager.AdvertisementConfig{
+		Enabled:                     enableRouterAdvertisement,
+		AdvertiseNsxConnectedRoutes: advConnected,
+		AdvertiseStaticRoutes:       advStatic,
+		AdvertiseNatRoutes:          advNat,
+		AdvertiseLbVip:              advLbVip,
+		AdvertiseLbSnatIp:           advLbSnatIP,
+	}
+	_, _, err := nsxClient.LogicalRoutingAndServicesApi.UpdateAdvertisementConfig(nsxClient.Context, id, advConfig)
+	return err
 }
 
 func resourceNsxtLogicalTier1RouterUpdateAdv(d *schema.ResourceData, nsxClient *api.APIClient, id string) error {
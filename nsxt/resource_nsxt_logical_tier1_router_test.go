@@ -62,6 +62,36 @@ func TestAccResourceNsxtLogicalTier1Router_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtLogicalTier1Router_advertisementDisabled(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_logical_tier1_router.test"
+	failoverMode := "PREEMPTIVE"
+	edgeClusterName := getEdgeClusterName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXLogicalTier1RouterCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Advertisement flags are applied on create even with advertisement disabled
+				Config: testAccNSXLogicalTier1RouterUpdateTemplate(name, failoverMode, edgeClusterName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXLogicalTier1RouterExists(name, testResourceName),
+					resource.TestCheckResourceAttr(testResourceName, "enable_router_advertisement", "false"),
+					resource.TestCheckResourceAttr(testResourceName, "advertise_connected_routes", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "advertise_static_routes", "false"),
+					resource.TestCheckResourceAttr(testResourceName, "advertise_nat_routes", "false"),
+					resource.TestCheckResourceAttr(testResourceName, "advertise_lb_vip_routes", "true"),
+					resource.TestCheckResourceAttr(testResourceName, "advertise_lb_snat_ip_routes", "true"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtLogicalTier1Router_importBasic(t *testing.T) {
 	name := getAccTestResourceName()
 	testResourceName := "nsxt_logical_tier1_router.test"
@@ -4,13 +4,16 @@
 package nsxt
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/vmware/go-vmware-nsxt"
 	"github.com/vmware/go-vmware-nsxt/manager"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
@@ -27,10 +30,15 @@ var natRuleActionValues = []string{
 
 func resourceNsxtNatRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNsxtNatRuleCreate,
-		Read:   resourceNsxtNatRuleRead,
-		Update: resourceNsxtNatRuleUpdate,
-		Delete: resourceNsxtNatRuleDelete,
+		CreateContext: withManagerDiagnostics(resourceNsxtNatRuleCreate),
+		ReadContext:   withManagerDiagnostics(resourceNsxtNatRuleRead),
+		UpdateContext: withManagerDiagnostics(resourceNsxtNatRuleUpdate),
+		DeleteContext: withManagerDiagnostics(resourceNsxtNatRuleDelete),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			State: resourceNsxtNatRuleImport,
 		},
@@ -73,14 +81,16 @@ func resourceNsxtNatRule() *schema.Resource {
 				Required:    true,
 			},
 			"match_destination_network": {
-				Type:        schema.TypeString,
-				Description: "IP Address | CIDR",
-				Optional:    true,
+				Type:         schema.TypeString,
+				Description:  "IPv4 or IPv6 Address | CIDR",
+				Optional:     true,
+				ValidateFunc: validateCidrOrIP(),
 			},
 			"match_source_network": {
-				Type:        schema.TypeString,
-				Description: "IP Address | CIDR",
-				Optional:    true,
+				Type:         schema.TypeString,
+				Description:  "IPv4 or IPv6 Address | CIDR",
+				Optional:     true,
+				ValidateFunc: validateCidrOrIP(),
 			},
 			"nat_pass": {
 				Type:        schema.TypeBool,
@@ -93,29 +103,89 @@ func resourceNsxtNatRule() *schema.Resource {
 				Description:  "The priority of the rule (ascending). Valid range [0-2147483647]",
 				Optional:     true,
 				Computed:     true,
-				ValidateFunc: validation.IntAtLeast(0),
+				ValidateFunc: validation.IntBetween(0, 2147483647),
 			},
 			"translated_network": {
-				Type:        schema.TypeString,
-				Description: "IP Address | IP Range | CIDR",
-				Optional:    true,
+				Type:         schema.TypeString,
+				Description:  "IP Address | IP Range | CIDR. For DNAT rules only a single IP is supported",
+				Optional:     true,
+				ValidateFunc: validateCidrOrIPOrRange(),
 			},
 			"translated_ports": {
 				Type:        schema.TypeString,
 				Description: "port number or port range. DNAT only",
 				Optional:    true,
 			},
-			//TODO(asarfaty): Add match_service field
+			// match_service is not exposed since NsServiceElement in the manager SDK
+			// only carries resource_type, and service entry details would be lost.
+			// nsxt_policy_nat_rule supports service matching via the service attribute.
+			// firewall_match and NAT64 are not available either, since the manager
+			// NatRule model predates them. Both are supported by nsxt_policy_nat_rule.
 		},
 	}
 }
 
-func resourceNsxtNatRuleCreate(d *schema.ResourceData, m interface{}) error {
+// validateNatRuleTranslation verifies translated fields are consistent with
+// the rule action, since NSX rejects inconsistent rules with generic errors
+func validateNatRuleTranslation(action string, translatedNetwork string, translatedPorts string, natPass bool) error {
+	switch action {
+	case "NO_NAT", model.PolicyNatRule_ACTION_NO_SNAT, model.PolicyNatRule_ACTION_NO_DNAT:
+		if translatedNetwork != "" {
+			return fmt.Errorf("translated_network can not be specified for %s action", action)
+		}
+		if translatedPorts != "" {
+			return fmt.Errorf("translated_ports can not be specified for %s action", action)
+		}
+		if action == "NO_NAT" && !natPass {
+			return fmt.Errorf("nat_pass must be true for NO_NAT action")
+		}
+	case model.PolicyNatRule_ACTION_SNAT, model.PolicyNatRule_ACTION_DNAT, model.PolicyNatRule_ACTION_REFLEXIVE:
+		if translatedNetwork == "" {
+			return fmt.Errorf("translated_network is required for %s action", action)
+		}
+		if action == model.PolicyNatRule_ACTION_DNAT && !isSingleIP(translatedNetwork) {
+			return fmt.Errorf("translated_network must be a single IP address for DNAT action, got %s", translatedNetwork)
+		}
+	}
+
+	if translatedPorts != "" && action != model.PolicyNatRule_ACTION_DNAT {
+		return fmt.Errorf("translated_ports is only valid for DNAT action, got %s", action)
+	}
+	return nil
+}
+
+// validateNatRuleHAMode rejects rules other than stateless REFLEXIVE on
+// routers in ACTIVE_ACTIVE high availability mode, which NSX does not support
+func validateNatRuleHAMode(action string, highAvailabilityMode string) error {
+	if highAvailabilityMode != "ACTIVE_ACTIVE" {
+		return nil
+	}
+	if action != model.PolicyNatRule_ACTION_REFLEXIVE {
+		return fmt.Errorf("%s action can not be used on a logical router in ACTIVE_ACTIVE high availability mode, only stateless REFLEXIVE action is supported", action)
+	}
+	return nil
+}
+
+func validateNatRuleLogicalRouter(ctx context.Context, nsxClient *api.APIClient, logicalRouterID string, action string) error {
+	logicalRouter, resp, err := nsxClient.LogicalRoutingAndServicesApi.ReadLogicalRouter(ctx, logicalRouterID)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("Logical router %s of NatRule was not found", logicalRouterID)
+	}
+	if err != nil {
+		return fmt.Errorf("Error during NatRule logical router %s read: %v", logicalRouterID, err)
+	}
+	return validateNatRuleHAMode(action, logicalRouter.HighAvailabilityMode)
+}
+
+func resourceNsxtNatRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx, cancel := getOperationContext(ctx, nsxClient, d, schema.TimeoutCreate)
+	defer cancel()
+
 	logicalRouterID := d.Get("logical_router_id").(string)
 	if logicalRouterID == "" {
 		return fmt.Errorf("Error obtaining logical object id")
@@ -137,6 +207,12 @@ func resourceNsxtNatRuleCreate(d *schema.ResourceData, m interface{}) error {
 	rulePriority := int64(d.Get("rule_priority").(int))
 	translatedNetwork := d.Get("translated_network").(string)
 	translatedPorts := d.Get("translated_ports").(string)
+	if err := validateNatRuleTranslation(action, translatedNetwork, translatedPorts, natPass); err != nil {
+		return err
+	}
+	if err := validateNatRuleLogicalRouter(ctx, nsxClient, logicalRouterID, action); err != nil {
+		return err
+	}
 	natRule := manager.NatRule{
 		Description:             description,
 		DisplayName:             displayName,
@@ -154,10 +230,10 @@ func resourceNsxtNatRuleCreate(d *schema.ResourceData, m interface{}) error {
 		TranslatedPorts:    translatedPorts,
 	}
 
-	natRule, resp, err := nsxClient.LogicalRoutingAndServicesApi.AddNatRule(nsxClient.Context, logicalRouterID, natRule)
+	natRule, resp, err := nsxClient.LogicalRoutingAndServicesApi.AddNatRule(ctx, logicalRouterID, natRule)
 
 	if err != nil {
-		return fmt.Errorf("Error during NatRule create: %v", err)
+		return handleManagerAPIError("Error during NatRule create", err)
 	}
 
 	if resp.StatusCode != http.StatusCreated {
@@ -165,15 +241,17 @@ func resourceNsxtNatRuleCreate(d *schema.ResourceData, m interface{}) error {
 	}
 	d.SetId(natRule.Id)
 
-	return resourceNsxtNatRuleRead(d, m)
+	return resourceNsxtNatRuleRead(ctx, d, m)
 }
 
-func resourceNsxtNatRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceNsxtNatRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx = getManagerContext(ctx, nsxClient)
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining logical object id")
@@ -184,7 +262,7 @@ func resourceNsxtNatRuleRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error obtaining logical object id")
 	}
 
-	natRule, resp, err := nsxClient.LogicalRoutingAndServicesApi.GetNatRule(nsxClient.Context, logicalRouterID, id)
+	natRule, resp, err := nsxClient.LogicalRoutingAndServicesApi.GetNatRule(ctx, logicalRouterID, id)
 	if resp != nil && (resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound) {
 		// Due to platform bug, 400 response is returned when NAT rule is not found
 		// In this case terraform should not error out
@@ -193,7 +271,7 @@ func resourceNsxtNatRuleRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf("Error during NatRule read: %v", err)
+		return handleManagerAPIError("Error during NatRule read", err)
 	}
 
 	d.Set("revision", natRule.Revision)
@@ -215,12 +293,15 @@ func resourceNsxtNatRuleRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNsxtNatRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceNsxtNatRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx, cancel := getOperationContext(ctx, nsxClient, d, schema.TimeoutUpdate)
+	defer cancel()
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining logical object id")
@@ -248,6 +329,14 @@ func resourceNsxtNatRuleUpdate(d *schema.ResourceData, m interface{}) error {
 	rulePriority := int64(d.Get("rule_priority").(int))
 	translatedNetwork := d.Get("translated_network").(string)
 	translatedPorts := d.Get("translated_ports").(string)
+	if err := validateNatRuleTranslation(action, translatedNetwork, translatedPorts, natPass); err != nil {
+		return err
+	}
+	if d.HasChange("action") {
+		if err := validateNatRuleLogicalRouter(ctx, nsxClient, logicalRouterID, action); err != nil {
+			return err
+		}
+	}
 	natRule := manager.NatRule{
 		Revision:                revision,
 		Description:             description,
@@ -266,21 +355,42 @@ func resourceNsxtNatRuleUpdate(d *schema.ResourceData, m interface{}) error {
 		TranslatedPorts:    translatedPorts,
 	}
 
-	_, resp, err := nsxClient.LogicalRoutingAndServicesApi.UpdateNatRule(nsxClient.Context, logicalRouterID, id, natRule)
+	resp, err := retryUponConflict(m.(nsxtClients).CommonConfig.AutoRetryOnConflict,
+		func() (*http.Response, error) {
+			_, resp, err := nsxClient.LogicalRoutingAndServicesApi.UpdateNatRule(ctx, logicalRouterID, id, natRule)
+			return resp, err
+		},
+		func() error {
+			currRule, _, err := nsxClient.LogicalRoutingAndServicesApi.GetNatRule(ctx, logicalRouterID, id)
+			natRule.Revision = currRule.Revision
+			return err
+		})
 
+	if resp != nil && (resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound) {
+		// Rule was deleted outside of terraform, it is removed from state on next
+		// refresh and recreated, similar to read. Due to platform bug, 400
+		// response may be returned when NAT rule is not found.
+		_, getResp, _ := nsxClient.LogicalRoutingAndServicesApi.GetNatRule(ctx, logicalRouterID, id)
+		if getResp != nil && (getResp.StatusCode == http.StatusBadRequest || getResp.StatusCode == http.StatusNotFound) {
+			return fmt.Errorf("NatRule %s was deleted outside of terraform and will be recreated on next apply", id)
+		}
+	}
 	if err != nil || resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("Error during NatRule update: %v", err)
+		return handleManagerAPIError("Error during NatRule update", err)
 	}
 
-	return resourceNsxtNatRuleRead(d, m)
+	return resourceNsxtNatRuleRead(ctx, d, m)
 }
 
-func resourceNsxtNatRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceNsxtNatRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	nsxClient := m.(nsxtClients).NsxtClient
 	if nsxClient == nil {
 		return resourceNotSupportedError()
 	}
 
+	ctx, cancel := getOperationContext(ctx, nsxClient, d, schema.TimeoutDelete)
+	defer cancel()
+
 	id := d.Id()
 	if id == "" {
 		return fmt.Errorf("Error obtaining logical object id")
@@ -290,9 +400,9 @@ func resourceNsxtNatRuleDelete(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error obtaining logical object id")
 	}
 
-	resp, err := nsxClient.LogicalRoutingAndServicesApi.DeleteNatRule(nsxClient.Context, logicalRouterID, id)
+	resp, err := nsxClient.LogicalRoutingAndServicesApi.DeleteNatRule(ctx, logicalRouterID, id)
 	if err != nil {
-		return fmt.Errorf("Error during NatRule delete: %v", err)
+		return handleManagerAPIError("Error during NatRule delete", err)
 	}
 
 	if resp.StatusCode == http.StatusNotFound {
@@ -305,8 +415,8 @@ func resourceNsxtNatRuleDelete(d *schema.ResourceData, m interface{}) error {
 func resourceNsxtNatRuleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	importID := d.Id()
 	s := strings.Split(importID, "/")
-	if len(s) != 2 {
-		return nil, fmt.Errorf("Please provide <router-id>/<nat-rule-id> as an input")
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		return nil, fmt.Errorf("Please provide <router-id>/<nat-rule-id> as an input, got %s", importID)
 	}
 	d.SetId(s[1])
 	d.Set("logical_router_id", s[0])
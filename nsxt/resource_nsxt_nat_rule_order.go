@@ -0,0 +1,259 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/vmware/go-vmware-nsxt"
+)
+
+func resourceNsxtNatRuleOrder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withManagerDiagnostics(resourceNsxtNatRuleOrderCreate),
+		ReadContext:   withManagerDiagnostics(resourceNsxtNatRuleOrderRead),
+		UpdateContext: withManagerDiagnostics(resourceNsxtNatRuleOrderUpdate),
+		DeleteContext: withManagerDiagnostics(resourceNsxtNatRuleOrderDelete),
+
+		Schema: map[string]*schema.Schema{
+			"logical_router_id": {
+				Type:         schema.TypeString,
+				Description:  "Logical router id of the NAT rules",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"rule_ids": {
+				Type:        schema.TypeList,
+				Description: "Ids of NAT rules in the order they should be evaluated",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"start_priority": {
+				Type:         schema.TypeInt,
+				Description:  "Priority assigned to the first rule",
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(0, 2147483647),
+			},
+			"priority_step": {
+				Type:         schema.TypeInt,
+				Description:  "Difference in priority between consecutive rules",
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+// isNatRuleOrderApplied returns whether priorities of the rules are strictly
+// ascending in the order of ruleIDs
+func isNatRuleOrderApplied(ruleIDs []string, priorities map[string]int64) bool {
+	for i := 1; i < len(ruleIDs); i++ {
+		if priorities[ruleIDs[i]] <= priorities[ruleIDs[i-1]] {
+			return false
+		}
+	}
+	return true
+}
+
+// getNatRulePriorityUpdates returns new priorities for rules that need to
+// change in order for ruleIDs to be evaluated in this order. No rules are
+// updated if the order is already in effect.
+func getNatRulePriorityUpdates(ruleIDs []string, priorities map[string]int64, startPriority int64, step int64) map[string]int64 {
+	updates := make(map[string]int64)
+	if isNatRuleOrderApplied(ruleIDs, priorities) {
+		return updates
+	}
+	for i, id := range ruleIDs {
+		priority := startPriority + int64(i)*step
+		if current, ok := priorities[id]; !ok || current != priority {
+			updates[id] = priority
+		}
+	}
+	return updates
+}
+
+// getNatRulesOrderByPriority returns rule ids sorted by their priority. Rules
+// with equal priority have undefined evaluation order, hence these are listed
+// in reverse of configured order in order to show as a diff.
+func getNatRulesOrderByPriority(ruleIDs []string, priorities map[string]int64) []string {
+	var order []string
+	for _, id := range ruleIDs {
+		if _, ok := priorities[id]; ok {
+			order = append(order, id)
+		}
+	}
+	index := make(map[string]int)
+	for i, id := range ruleIDs {
+		index[id] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if priorities[order[i]] == priorities[order[j]] {
+			return index[order[i]] > index[order[j]]
+		}
+		return priorities[order[i]] < priorities[order[j]]
+	})
+	return order
+}
+
+// getNatRulePriorities returns current priorities of the rules, listing all
+// rules of the logical router rather than reading each rule. Rules that do not
+// exist on NSX are omitted.
+func getNatRulePriorities(ctx context.Context, nsxClient *api.APIClient, logicalRouterID string, ruleIDs []string) (map[string]int64, error) {
+	wanted := make(map[string]bool)
+	for _, id := range ruleIDs {
+		wanted[id] = true
+	}
+
+	priorities := make(map[string]int64)
+	lister := func(info *paginationInfo) error {
+		objList, resp, err := nsxClient.LogicalRoutingAndServicesApi.ListNatRules(ctx, logicalRouterID, info.LocalVarOptionals)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			log.Printf("[DEBUG] Logical router %s not found", logicalRouterID)
+			return nil
+		}
+		if err != nil {
+			return handleManagerAPIError(fmt.Sprintf("Error while listing NatRules of logical router %s", logicalRouterID), err)
+		}
+
+		info.PageCount = int64(len(objList.Results))
+		info.TotalCount = objList.ResultCount
+		info.Cursor = objList.Cursor
+
+		for _, natRule := range objList.Results {
+			if wanted[natRule.Id] {
+				priorities[natRule.Id] = natRule.RulePriority
+			}
+		}
+		return nil
+	}
+
+	_, err := handlePagination(lister)
+	if err != nil {
+		return nil, err
+	}
+	return priorities, nil
+}
+
+func resourceNsxtNatRuleOrderApply(ctx context.Context, d *schema.ResourceData, m interface{}, nsxClient *api.APIClient) error {
+	logicalRouterID := d.Get("logical_router_id").(string)
+	ruleIDs := interface2StringList(d.Get("rule_ids").([]interface{}))
+	startPriority := int64(d.Get("start_priority").(int))
+	step := int64(d.Get("priority_step").(int))
+
+	priorities, err := getNatRulePriorities(ctx, nsxClient, logicalRouterID, ruleIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range ruleIDs {
+		if _, ok := priorities[id]; !ok {
+			return fmt.Errorf("NatRule %s was not found on logical router %s", id, logicalRouterID)
+		}
+	}
+
+	updates := getNatRulePriorityUpdates(ruleIDs, priorities, startPriority, step)
+	for _, id := range ruleIDs {
+		priority, ok := updates[id]
+		if !ok {
+			continue
+		}
+		log.Printf("[INFO] Setting priority of NatRule %s to %d", id, priority)
+		natRule, _, err := nsxClient.LogicalRoutingAndServicesApi.GetNatRule(ctx, logicalRouterID, id)
+		if err != nil {
+			return handleManagerAPIError(fmt.Sprintf("Error during NatRule %s read", id), err)
+		}
+		natRule.RulePriority = priority
+		_, err = retryUponConflict(m.(nsxtClients).CommonConfig.AutoRetryOnConflict,
+			func() (*http.Response, error) {
+				_, resp, err := nsxClient.LogicalRoutingAndServicesApi.UpdateNatRule(ctx, logicalRouterID, id, natRule)
+				return resp, err
+			},
+			func() error {
+				currRule, _, err := nsxClient.LogicalRoutingAndServicesApi.GetNatRule(ctx, logicalRouterID, id)
+				natRule.Revision = currRule.Revision
+				return err
+			})
+		if err != nil {
+			return handleManagerAPIError(fmt.Sprintf("Error during NatRule %s priority update", id), err)
+		}
+	}
+
+	return nil
+}
+
+func resourceNsxtNatRuleOrderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return resourceNotSupportedError()
+	}
+
+	ctx = getManagerContext(ctx, nsxClient)
+	err := resourceNsxtNatRuleOrderApply(ctx, d, m, nsxClient)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(newUUID())
+	return resourceNsxtNatRuleOrderRead(ctx, d, m)
+}
+
+func resourceNsxtNatRuleOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return resourceNotSupportedError()
+	}
+
+	ctx = getManagerContext(ctx, nsxClient)
+	logicalRouterID := d.Get("logical_router_id").(string)
+	ruleIDs := interface2StringList(d.Get("rule_ids").([]interface{}))
+	priorities, err := getNatRulePriorities(ctx, nsxClient, logicalRouterID, ruleIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(priorities) == 0 {
+		log.Printf("[DEBUG] None of NatRules %v were found", ruleIDs)
+		d.SetId("")
+		return nil
+	}
+	if !isNatRuleOrderApplied(ruleIDs, priorities) || len(priorities) != len(ruleIDs) {
+		// Ordering in state reflects evaluation order on NSX, so that any
+		// deviation from configured order shows as a diff
+		d.Set("rule_ids", getNatRulesOrderByPriority(ruleIDs, priorities))
+	}
+
+	return nil
+}
+
+func resourceNsxtNatRuleOrderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return resourceNotSupportedError()
+	}
+
+	ctx = getManagerContext(ctx, nsxClient)
+	err := resourceNsxtNatRuleOrderApply(ctx, d, m, nsxClient)
+	if err != nil {
+		return err
+	}
+
+	return resourceNsxtNatRuleOrderRead(ctx, d, m)
+}
+
+func resourceNsxtNatRuleOrderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	// Rules keep their current priorities
+	return nil
+}
@@ -0,0 +1,153 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceNsxtNatRuleOrder_basic(t *testing.T) {
+	ruleName := getAccTestResourceName()
+	edgeClusterName := getEdgeClusterName()
+	testResourceName := "nsxt_nat_rule_order.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccTestMP(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXNATRuleCheckDestroy(state, ruleName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXNATRuleOrderTemplate(ruleName, edgeClusterName, "rule2", "rule1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "rule_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(testResourceName, "rule_ids.0", "nsxt_nat_rule.rule2", "id"),
+					resource.TestCheckResourceAttrPair(testResourceName, "rule_ids.1", "nsxt_nat_rule.rule1", "id"),
+				),
+			},
+			{
+				Config: testAccNSXNATRuleOrderTemplate(ruleName, edgeClusterName, "rule1", "rule2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testResourceName, "rule_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(testResourceName, "rule_ids.0", "nsxt_nat_rule.rule1", "id"),
+					resource.TestCheckResourceAttrPair(testResourceName, "rule_ids.1", "nsxt_nat_rule.rule2", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetNatRulePriorityUpdates(t *testing.T) {
+	ruleIDs := []string{"a", "b", "c"}
+
+	// Order is in effect, even though priorities differ from computed ones
+	updates := getNatRulePriorityUpdates(ruleIDs, map[string]int64{"a": 5, "b": 7, "c": 1000}, 100, 10)
+	if len(updates) != 0 {
+		t.Errorf("Expected no updates, got %v", updates)
+	}
+
+	// Rules with equal priority have undefined order
+	updates = getNatRulePriorityUpdates(ruleIDs, map[string]int64{"a": 100, "b": 100, "c": 120}, 100, 10)
+	expected := map[string]int64{"b": 110}
+	if !reflect.DeepEqual(updates, expected) {
+		t.Errorf("Expected updates %v, got %v", expected, updates)
+	}
+
+	updates = getNatRulePriorityUpdates(ruleIDs, map[string]int64{"a": 3, "b": 2, "c": 1}, 100, 10)
+	expected = map[string]int64{"a": 100, "b": 110, "c": 120}
+	if !reflect.DeepEqual(updates, expected) {
+		t.Errorf("Expected updates %v, got %v", expected, updates)
+	}
+}
+
+func TestGetNatRulePriorities(t *testing.T) {
+	routerExists := true
+	listCalls := 0
+	nsxClient, closeServer := newTestManagerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		listCalls++
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != "GET" || r.URL.Path != "/api/v1/logical-routers/router-1/nat/rules" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if !routerExists {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error_code": 202, "error_message": "The requested object could not be found"}`)
+			return
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"result_count": 3, "cursor": "2", "results": [{"id": "a", "rule_priority": 300}, {"id": "other", "rule_priority": 50}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"result_count": 3, "results": [{"id": "b", "rule_priority": 100}]}`)
+	})
+	defer closeServer()
+
+	// All pages are listed, and only requested rules are returned
+	priorities, err := getNatRulePriorities(context.Background(), nsxClient, "router-1", []string{"a", "b", "missing"})
+	expected := map[string]int64{"a": 300, "b": 100}
+	if err != nil || !reflect.DeepEqual(priorities, expected) || listCalls != 2 {
+		t.Errorf("Expected priorities %v in 2 list calls, got %v in %d calls, error %v", expected, priorities, listCalls, err)
+	}
+
+	// Rules of a deleted router do not exist
+	routerExists = false
+	priorities, err = getNatRulePriorities(context.Background(), nsxClient, "router-1", []string{"a"})
+	if err != nil || len(priorities) != 0 {
+		t.Errorf("Expected no priorities for deleted router, got %v, error %v", priorities, err)
+	}
+}
+
+func TestGetNatRulesOrderByPriority(t *testing.T) {
+	ruleIDs := []string{"a", "b", "c"}
+	cases := []struct {
+		priorities map[string]int64
+		expected   []string
+	}{
+		{map[string]int64{"a": 1, "b": 2, "c": 3}, []string{"a", "b", "c"}},
+		{map[string]int64{"a": 3, "b": 2, "c": 1}, []string{"c", "b", "a"}},
+		{map[string]int64{"a": 1, "b": 1, "c": 3}, []string{"b", "a", "c"}},
+		{map[string]int64{"a": 1, "c": 3}, []string{"a", "c"}},
+	}
+
+	for _, c := range cases {
+		order := getNatRulesOrderByPriority(ruleIDs, c.priorities)
+		if !reflect.DeepEqual(order, c.expected) {
+			t.Errorf("Expected order %v for priorities %v, got %v", c.expected, c.priorities, order)
+		}
+	}
+}
+
+func testAccNSXNATRuleOrderTemplate(name string, edgeClusterName string, first string, second string) string {
+	return testAccNSXNATRulePreConditionTemplate(edgeClusterName) + fmt.Sprintf(`
+resource "nsxt_nat_rule" "rule1" {
+  logical_router_id         = "${nsxt_logical_tier1_router.rtr1.id}"
+  display_name              = "%s"
+  action                    = "SNAT"
+  translated_network        = "4.4.4.0/24"
+  match_destination_network = "3.3.3.0/24"
+  match_source_network      = "5.5.5.0/24"
+}
+
+resource "nsxt_nat_rule" "rule2" {
+  logical_router_id         = "${nsxt_logical_tier1_router.rtr1.id}"
+  display_name              = "%s"
+  action                    = "SNAT"
+  translated_network        = "4.4.5.0/24"
+  match_destination_network = "3.3.3.0/24"
+  match_source_network      = "5.5.6.0/24"
+}
+
+resource "nsxt_nat_rule_order" "test" {
+  logical_router_id = "${nsxt_logical_tier1_router.rtr1.id}"
+  rule_ids          = ["${nsxt_nat_rule.%s.id}", "${nsxt_nat_rule.%s.id}"]
+}`, name, name, first, second)
+}
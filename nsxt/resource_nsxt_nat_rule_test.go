@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	api "github.com/vmware/go-vmware-nsxt"
 )
 
 var testAccResourceNatRuleName = "nsxt_nat_rule.test"
@@ -196,64 +197,117 @@ func TestAccResourceNsxtNatRule_noNnat(t *testing.T) {
 	})
 }
 
-func testAccNSXNATRuleCheckExists(displayName string, resourceName string) resource.TestCheckFunc {
-	return func(state *terraform.State) error {
+func TestAccResourceNsxtNatRule_noNatIPv6(t *testing.T) {
+	ruleName := getAccTestResourceName()
+	edgeClusterName := getEdgeClusterName()
 
-		nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccOnlyLocalManager(t)
+			testAccTestMP(t)
+			testAccPreCheck(t)
+			testAccNSXVersionLessThan(t, "3.0.0")
+		},
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXNATRuleCheckDestroy(state, ruleName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXNoNATRuleIPv6Template(ruleName, edgeClusterName, "NO_NAT"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXNATRuleCheckExists(ruleName, testAccResourceNatRuleName),
+					resource.TestCheckResourceAttr(testAccResourceNatRuleName, "action", "NO_NAT"),
+					resource.TestCheckResourceAttr(testAccResourceNatRuleName, "match_destination_network", "2001:db8:3::/64"),
+					resource.TestCheckResourceAttr(testAccResourceNatRuleName, "match_source_network", "2001:db8:5::1"),
+				),
+			},
+		},
+	})
+}
 
-		rs, ok := state.RootModule().Resources[resourceName]
-		if !ok {
-			return fmt.Errorf("NSX nat rule resource %s not found in resources", resourceName)
-		}
+func TestAccResourceNsxtNatRule_noSnatIPv6(t *testing.T) {
+	ruleName := getAccTestResourceName()
+	edgeClusterName := getEdgeClusterName()
 
-		resourceID := rs.Primary.ID
-		if resourceID == "" {
-			return fmt.Errorf("NSX nat rule resource ID not set in resources ")
-		}
-		routerID := rs.Primary.Attributes["logical_router_id"]
-		if routerID == "" {
-			return fmt.Errorf("NSX nat rule routerID not set in resources ")
-		}
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccOnlyLocalManager(t)
+			testAccTestMP(t)
+			testAccPreCheck(t)
+			testAccNSXVersion(t, "3.0.0")
+		},
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNSXNATRuleCheckDestroy(state, ruleName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXNoNATRuleIPv6Template(ruleName, edgeClusterName, "NO_SNAT"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNSXNATRuleCheckExists(ruleName, testAccResourceNatRuleName),
+					resource.TestCheckResourceAttr(testAccResourceNatRuleName, "action", "NO_SNAT"),
+					resource.TestCheckResourceAttr(testAccResourceNatRuleName, "match_destination_network", "2001:db8:3::/64"),
+					resource.TestCheckResourceAttr(testAccResourceNatRuleName, "match_source_network", "2001:db8:5::1"),
+				),
+			},
+		},
+	})
+}
 
-		natRule, responseCode, err := nsxClient.LogicalRoutingAndServicesApi.GetNatRule(nsxClient.Context, routerID, resourceID)
-		if err != nil {
-			return fmt.Errorf("Error while retrieving nat rule ID %s. Error: %v", resourceID, err)
-		}
+func TestValidateNatRuleTranslation(t *testing.T) {
+	cases := []struct {
+		action            string
+		translatedNetwork string
+		translatedPorts   string
+		natPass           bool
+		valid             bool
+	}{
+		{"SNAT", "4.4.4.0/24", "", false, true},
+		{"SNAT", "", "", true, false},
+		{"SNAT", "4.4.4.0/24", "80", true, false},
+		{"DNAT", "4.4.4.4", "80-81", true, true},
+		{"DNAT", "", "80", true, false},
+		{"DNAT", "4.4.4.1-4.4.4.10", "", true, false},
+		{"DNAT", "4.4.4.0/24", "", true, false},
+		{"SNAT", "4.4.4.1-4.4.4.10", "", true, true},
+		{"SNAT", "4.4.4.4", "", true, true},
+		{"REFLEXIVE", "", "", true, false},
+		{"NO_NAT", "", "", true, true},
+		{"NO_NAT", "", "", false, false},
+		{"NO_NAT", "4.4.4.4", "", true, false},
+		{"NO_DNAT", "", "80", true, false},
+		{"NO_SNAT", "", "", false, true},
+	}
 
-		if responseCode.StatusCode != http.StatusOK {
-			return fmt.Errorf("Error while checking if nat rule %s exists. HTTP return code was %d", resourceID, responseCode.StatusCode)
+	for _, c := range cases {
+		err := validateNatRuleTranslation(c.action, c.translatedNetwork, c.translatedPorts, c.natPass)
+		if c.valid && err != nil {
+			t.Errorf("Expected %+v to be valid, got error: %v", c, err)
 		}
-
-		if displayName == natRule.DisplayName {
-			return nil
+		if !c.valid && err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
 		}
-		return fmt.Errorf("NSX nat rule %s wasn't found", displayName)
 	}
 }
 
-func testAccNSXNATRuleCheckDestroy(state *terraform.State, displayName string) error {
-	nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
-	for _, rs := range state.RootModule().Resources {
-
-		if rs.Type != "nsxt_nat_rule" {
-			continue
-		}
+func testAccNSXNATRuleCheckExists(displayName string, resourceName string) resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		testAccNSXResourceExists(resourceName, displayName, testAccNSXNATRulePresence),
+		resource.TestCheckResourceAttr(resourceName, "display_name", displayName),
+	)
+}
 
-		resourceID := rs.Primary.Attributes["id"]
-		routerID := rs.Primary.Attributes["logical_router_id"]
-		natRule, responseCode, err := nsxClient.LogicalRoutingAndServicesApi.GetNatRule(nsxClient.Context, routerID, resourceID)
-		if err != nil {
-			if responseCode.StatusCode != http.StatusOK {
-				return nil
-			}
-			return fmt.Errorf("Error while retrieving nat rule ID %s. Error: %v", resourceID, err)
-		}
+func testAccNSXNATRulePresence(nsxClient *api.APIClient, rs *terraform.ResourceState) (string, bool, error) {
+	routerID := rs.Primary.Attributes["logical_router_id"]
+	natRule, resp, err := nsxClient.LogicalRoutingAndServicesApi.GetNatRule(nsxClient.Context, routerID, rs.Primary.ID)
+	// Due to platform bug, 400 response is returned when NAT rule is not found
+	exists, err := testAccNSXResourcePresence(resp, err, http.StatusBadRequest)
+	return natRule.DisplayName, exists, err
+}
 
-		if displayName == natRule.DisplayName {
-			return fmt.Errorf("NSX nat rule %s still exists", displayName)
-		}
-	}
-	return nil
+func testAccNSXNATRuleCheckDestroy(state *terraform.State, displayName string) error {
+	return testAccNSXResourceCheckDestroy(state, displayName, "nsxt_nat_rule", testAccNSXNATRulePresence)
 }
 
 func testAccNSXNATRuleImporterGetID(s *terraform.State) (string, error) {
@@ -397,3 +451,45 @@ resource "nsxt_nat_rule" "test" {
   }
 }`, name)
 }
+
+func TestValidateNatRuleHAMode(t *testing.T) {
+	cases := []struct {
+		action string
+		haMode string
+		valid  bool
+	}{
+		{"SNAT", "ACTIVE_STANDBY", true},
+		{"DNAT", "ACTIVE_STANDBY", true},
+		{"SNAT", "", true},
+		{"SNAT", "ACTIVE_ACTIVE", false},
+		{"DNAT", "ACTIVE_ACTIVE", false},
+		{"REFLEXIVE", "ACTIVE_ACTIVE", true},
+		{"REFLEXIVE", "ACTIVE_STANDBY", true},
+		{"NO_NAT", "ACTIVE_ACTIVE", false},
+		{"NO_SNAT", "ACTIVE_ACTIVE", false},
+		{"NO_DNAT", "ACTIVE_ACTIVE", false},
+		{"NO_SNAT", "ACTIVE_STANDBY", true},
+	}
+
+	for _, c := range cases {
+		err := validateNatRuleHAMode(c.action, c.haMode)
+		if c.valid && err != nil {
+			t.Errorf("Expected %+v to be valid, got error: %v", c, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
+
+func testAccNSXNoNATRuleIPv6Template(name string, edgeClusterName string, action string) string {
+	return testAccNSXNATRulePreConditionTemplate(edgeClusterName) + fmt.Sprintf(`
+resource "nsxt_nat_rule" "test" {
+  logical_router_id         = "${nsxt_logical_tier1_router.rtr1.id}"
+  display_name              = "%s"
+  action                    = "%s"
+  match_destination_network = "2001:db8:3::/64"
+  match_source_network      = "2001:db8:5::1"
+  nat_pass                  = "true"
+}`, name, action)
+}
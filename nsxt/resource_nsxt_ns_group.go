@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vmware-nsxt/common"
 	"github.com/vmware/go-vmware-nsxt/manager"
 )
 
@@ -37,7 +38,8 @@ func resourceNsxtNsGroup() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
-			"tag": getTagsSchema(),
+			"tag":       getTagsSchema(),
+			"tag_merge": getTagMergeSchema(),
 			"member": {
 				Type:        schema.TypeSet,
 				Description: "Reference to the direct/static members of the NSGroup.",
@@ -114,6 +116,19 @@ func getMembershipCriteriaFromSchema(d *schema.ResourceData) []manager.NsGroupTa
 	return expresionList
 }
 
+// validateMembershipCriteria makes sure each tag expression can actually match
+// something, since NSX accepts expressions with both scope and tag empty
+func validateMembershipCriteria(d *schema.ResourceData) error {
+	criteriaList := d.Get("membership_criteria").([]interface{})
+	for i, criteria := range criteriaList {
+		data := criteria.(map[string]interface{})
+		if data["scope"].(string) == "" && data["tag"].(string) == "" {
+			return fmt.Errorf("membership_criteria #%d: at least one of scope or tag must be specified", i+1)
+		}
+	}
+	return nil
+}
+
 func setMembershipCriteriaInSchema(d *schema.ResourceData, membershipCriterias []manager.NsGroupTagExpression) error {
 	var expresionList []map[string]interface{}
 	for _, criteria := range membershipCriterias {
@@ -164,6 +179,10 @@ func resourceNsxtNsGroupCreate(d *schema.ResourceData, m interface{}) error {
 		return resourceNotSupportedError()
 	}
 
+	if err := validateMembershipCriteria(d); err != nil {
+		return err
+	}
+
 	description := d.Get("description").(string)
 	displayName := d.Get("display_name").(string)
 	tags := getTagsFromSchema(d)
@@ -218,7 +237,7 @@ func resourceNsxtNsGroupRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("revision", nsGroup.Revision)
 	d.Set("description", nsGroup.Description)
 	d.Set("display_name", nsGroup.DisplayName)
-	setTagsInSchema(d, nsGroup.Tags)
+	setMergedTagsInSchema(d, nsGroup.Tags)
 	err1 := setMembersInSchema(d, nsGroup.Members)
 
 	err2 := setMembershipCriteriaInSchema(d, nsGroup.MembershipCriteria)
@@ -240,10 +259,21 @@ func resourceNsxtNsGroupUpdate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error obtaining logical object id")
 	}
 
+	if err := validateMembershipCriteria(d); err != nil {
+		return err
+	}
+
 	revision := int64(d.Get("revision").(int))
 	description := d.Get("description").(string)
 	displayName := d.Get("display_name").(string)
-	tags := getTagsFromSchema(d)
+	tags, err := getMergedTagsFromSchema(d, func() ([]common.Tag, error) {
+		localVarOptionals := make(map[string]interface{})
+		currNsGroup, _, err := nsxClient.GroupingObjectsApi.ReadNSGroup(nsxClient.Context, id, localVarOptionals)
+		return currNsGroup.Tags, err
+	})
+	if err != nil {
+		return fmt.Errorf("Error during NsGroup update: %v", err)
+	}
 	members := getMembersFromSchema(d)
 	membershipCriteria := getMembershipCriteriaFromSchema(d)
 	nsGroup := manager.NsGroup{
@@ -6,6 +6,7 @@ package nsxt
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -96,6 +97,10 @@ func TestAccResourceNsxtNSGroup_withCriteria(t *testing.T) {
 			return testAccNSXNSGroupCheckDestroy(state, grpName)
 		},
 		Steps: []resource.TestStep{
+			{
+				Config:      testAccNSXNSGroupEmptyCriteriaTemplate(grpName),
+				ExpectError: regexp.MustCompile(`at least one of scope or tag must be specified`),
+			},
 			{
 				Config: testAccNSXNSGroupCriteriaCreateTemplate(grpName),
 				Check: resource.ComposeTestCheckFunc(
@@ -297,6 +302,18 @@ resource "nsxt_ns_group" "test" {
 }`, updatedName)
 }
 
+func testAccNSXNSGroupEmptyCriteriaTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "nsxt_ns_group" "test" {
+  display_name = "%s"
+  description  = "Acceptance Test"
+
+  membership_criteria {
+    target_type = "LogicalSwitch"
+  }
+}`, name)
+}
+
 func testAccNSXNSGroupCriteriaCreateTemplate(name string) string {
 	return fmt.Sprintf(`
 resource "nsxt_ns_group" "test" {
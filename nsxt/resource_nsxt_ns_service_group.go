@@ -37,6 +37,11 @@ func resourceNsxtNsServiceGroup() *schema.Resource {
 				Computed:    true,
 			},
 			"tag": getTagsSchema(),
+			"default_service": {
+				Type:        schema.TypeBool,
+				Description: "A boolean flag which reflects whether this is a default NSServiceGroup which can't be modified/deleted",
+				Computed:    true,
+			},
 			"members": {
 				Type:        schema.TypeSet,
 				Description: "List of NSService or NSServiceGroup resources that can be added as members to an NSServiceGroup",
@@ -128,6 +133,7 @@ func resourceNsxtNsServiceGroupRead(d *schema.ResourceData, m interface{}) error
 	d.Set("description", nsServiceGroup.Description)
 	d.Set("display_name", nsServiceGroup.DisplayName)
 	setTagsInSchema(d, nsServiceGroup.Tags)
+	d.Set("default_service", nsServiceGroup.DefaultService)
 	d.Set("members", returnResourceReferencesTargetIDs(nsServiceGroup.Members))
 
 	return nil
@@ -178,6 +184,10 @@ func resourceNsxtNsServiceGroupDelete(d *schema.ResourceData, m interface{}) err
 		return fmt.Errorf("Error obtaining logical object id")
 	}
 
+	if d.Get("default_service").(bool) {
+		return fmt.Errorf("NsServiceGroup %s is a default service group and can not be deleted", id)
+	}
+
 	localVarOptionals := make(map[string]interface{})
 	localVarOptionals["force"] = true
 	resp, err := nsxClient.GroupingObjectsApi.DeleteNSServiceGroup(nsxClient.Context, id, localVarOptionals)
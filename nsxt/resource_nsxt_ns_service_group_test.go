@@ -32,6 +32,7 @@ func TestAccResourceNsxtNsServiceGroup_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(testResourceName, "description", "service group"),
 					resource.TestCheckResourceAttr(testResourceName, "tag.#", "1"),
 					resource.TestCheckResourceAttr(testResourceName, "members.#", "1"),
+					resource.TestCheckResourceAttr(testResourceName, "default_service", "false"),
 				),
 			},
 			{
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	gm_domains "github.com/vmware/vsphere-automation-sdk-go/services/nsxt-gm/global_infra/domains"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/domains"
@@ -33,6 +34,63 @@ func TestAccResourceNsxtPolicyGroup_basicImport(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtPolicyGroup_importByPath(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyGroupCheckDestroy(state, name, defaultDomain)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyGroupIPAddressImportTemplate(name),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNSXPolicyGroupImporterGetPath,
+			},
+		},
+	})
+}
+
+func TestNsxtDomainResourcePathImporter(t *testing.T) {
+	cases := []struct {
+		importID string
+		id       string
+		domain   string
+		fail     bool
+	}{
+		{"group1", "group1", defaultDomain, false},
+		{"domain1/group1", "group1", "domain1", false},
+		{"/infra/domains/domain1/groups/group1", "group1", "domain1", false},
+		{"/global-infra/domains/default/groups/group1", "group1", "default", false},
+		{"/infra/domains/domain1/security-policies/policy1", "", "", true},
+		{"/infra/segments/group1", "", "", true},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceNsxtPolicyGroup().Schema, map[string]interface{}{})
+		d.SetId(c.importID)
+		_, err := nsxtDomainResourcePathImporter("groups")(d, nil)
+		if c.fail {
+			if err == nil {
+				t.Errorf("Expected import of %s to fail", c.importID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for import of %s: %v", c.importID, err)
+		} else if d.Id() != c.id || d.Get("domain").(string) != c.domain {
+			t.Errorf("Expected id %s in domain %s for import of %s, got %s in domain %s", c.id, c.domain, c.importID, d.Id(), d.Get("domain"))
+		}
+	}
+}
+
 func TestAccResourceNsxtPolicyGroup_AddressCriteria(t *testing.T) {
 	name := getAccTestResourceName()
 	testResourceName := "nsxt_policy_group.test"
@@ -1348,3 +1406,15 @@ resource "nsxt_policy_group" "test" {
 }
 `, name)
 }
+
+func testAccNSXPolicyGroupImporterGetPath(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["nsxt_policy_group.test"]
+	if !ok {
+		return "", fmt.Errorf("NSX Policy Group resource not found in resources")
+	}
+	path := rs.Primary.Attributes["path"]
+	if path == "" {
+		return "", fmt.Errorf("NSX Policy Group path not set in resources")
+	}
+	return path, nil
+}
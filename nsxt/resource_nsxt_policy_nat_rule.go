@@ -251,6 +251,22 @@ func getTranslatedNetworks(rule model.PolicyNatRule) (*string, error) {
 	return tNets, nil
 }
 
+// validatePolicyNATRuleTranslation verifies that translation attributes are
+// in line with the rule action
+func validatePolicyNATRuleTranslation(action string, translatedNetworks []string, translatedPorts string) error {
+	if !translatedNetworksNeeded(action) {
+		if len(translatedNetworks) > 0 {
+			return fmt.Errorf("translated_networks can not be specified for %s action", action)
+		}
+	} else if len(translatedNetworks) == 0 {
+		return fmt.Errorf("translated_networks is required for %s action", action)
+	}
+	if translatedPorts != "" && action != model.PolicyNatRule_ACTION_DNAT {
+		return fmt.Errorf("translated_ports is only valid for DNAT action, got %s", action)
+	}
+	return nil
+}
+
 func resourceNsxtPolicyNATRuleRead(d *schema.ResourceData, m interface{}) error {
 	connector := getPolicyConnector(m)
 
@@ -313,6 +329,11 @@ func resourceNsxtPolicyNATRuleCreate(d *schema.ResourceData, m interface{}) erro
 	}
 	isGlobalManager := isPolicyGlobalManager(m)
 
+	err := validatePolicyNATRuleTranslation(action, interfaceListToStringList(d.Get("translated_networks").([]interface{})), d.Get("translated_ports").(string))
+	if err != nil {
+		return err
+	}
+
 	id := d.Get("nsx_id").(string)
 	if id == "" {
 		id = newUUID()
@@ -365,7 +386,7 @@ func resourceNsxtPolicyNATRuleCreate(d *schema.ResourceData, m interface{}) erro
 
 	log.Printf("[INFO] Creating NAT Rule with ID %s", id)
 
-	err := patchNsxtPolicyNATRule(connector, gwID, ruleStruct, isT0, isGlobalManager)
+	err = patchNsxtPolicyNATRule(connector, gwID, ruleStruct, isT0, isGlobalManager)
 	if err != nil {
 		return handleCreateError("NAT Rule", id, err)
 	}
@@ -393,6 +414,10 @@ func resourceNsxtPolicyNATRuleUpdate(d *schema.ResourceData, m interface{}) erro
 	displayName := d.Get("display_name").(string)
 	description := d.Get("description").(string)
 	action := d.Get("action").(string)
+	err := validatePolicyNATRuleTranslation(action, interfaceListToStringList(d.Get("translated_networks").([]interface{})), d.Get("translated_ports").(string))
+	if err != nil {
+		return err
+	}
 	enabled := d.Get("enabled").(bool)
 	logging := d.Get("logging").(bool)
 	priority := int64(d.Get("rule_priority").(int))
@@ -430,7 +455,7 @@ func resourceNsxtPolicyNATRuleUpdate(d *schema.ResourceData, m interface{}) erro
 	}
 
 	log.Printf("[INFO] Updating NAT Rule with ID %s", id)
-	err := patchNsxtPolicyNATRule(connector, gwID, ruleStruct, isT0, isPolicyGlobalManager(m))
+	err = patchNsxtPolicyNATRule(connector, gwID, ruleStruct, isT0, isPolicyGlobalManager(m))
 	if err != nil {
 		return handleUpdateError("NAT Rule", id, err)
 	}
@@ -443,9 +468,12 @@ func resourceNsxtPolicyNATRuleUpdate(d *schema.ResourceData, m interface{}) erro
 
 func resourceNsxtPolicyNATRuleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	importID := d.Id()
+	if isPolicyPath(importID) {
+		return resourceNsxtPolicyNATRuleImportByPath(d, importID)
+	}
 	s := strings.Split(importID, "/")
 	if len(s) < 2 || len(s) > 3 {
-		return nil, fmt.Errorf("Please provide <gateway-id>/<nat-rule-id>/[nat-type] as an input")
+		return nil, fmt.Errorf("Please provide <gateway-id>/<nat-rule-id>/[nat-type] or policy path of the rule as an input")
 	}
 
 	if len(s) == 3 {
@@ -495,3 +523,20 @@ func resourceNsxtPolicyNATRuleImport(d *schema.ResourceData, m interface{}) ([]*
 	return []*schema.ResourceData{d}, nil
 
 }
+
+// resourceNsxtPolicyNATRuleImportByPath handles import by rule policy path,
+// such as /infra/tier-1s/<gateway-id>/nat/USER/nat-rules/<nat-rule-id>
+func resourceNsxtPolicyNATRuleImportByPath(d *schema.ResourceData, importPath string) ([]*schema.ResourceData, error) {
+	segs := strings.Split(importPath, "/")
+	if len(segs) != 8 || segs[4] != "nat" || segs[6] != "nat-rules" || (segs[2] != "tier-0s" && segs[2] != "tier-1s") {
+		return nil, fmt.Errorf("Import path %s is not a NAT rule path", importPath)
+	}
+
+	if segs[5] == model.PolicyNat_NAT_TYPE_NAT64 {
+		d.Set("action", model.PolicyNatRule_ACTION_NAT64)
+	}
+	d.Set("gateway_path", strings.Join(segs[:4], "/"))
+	d.SetId(segs[7])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
@@ -208,6 +209,30 @@ func TestAccResourceNsxtPolicyNATRule_basicT1Import(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtPolicyNATRule_basicT1ImportByPath(t *testing.T) {
+	name := getAccTestResourceName()
+	action := model.PolicyNatRule_ACTION_DNAT
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyNATRuleCheckDestroy(state, name, false)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyNATRuleTier1CreateTemplate(name, action, testAccResourcePolicyNATRuleSourceNet, testAccResourcePolicyNATRuleDestNet, testAccResourcePolicyNATRuleTransNet),
+			},
+			{
+				ResourceName:      testAccResourcePolicyNATRuleName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNSXPolicyNATRuleImporterGetPath,
+			},
+		},
+	})
+}
+
 func TestAccResourceNsxtPolicyNATRule_nat64T1(t *testing.T) {
 	name := getAccTestResourceName()
 	updateName := getAccTestResourceName()
@@ -340,6 +365,58 @@ func testAccNSXPolicyNATRuleImporterGetID(s *terraform.State) (string, error) {
 	return fmt.Sprintf("%s/%s", gwID, resourceID), nil
 }
 
+func testAccNSXPolicyNATRuleImporterGetPath(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources[testAccResourcePolicyNATRuleName]
+	if !ok {
+		return "", fmt.Errorf("NSX Policy NAT Rule resource %s not found in resources", testAccResourcePolicyNATRuleName)
+	}
+	path := rs.Primary.Attributes["path"]
+	if path == "" {
+		return "", fmt.Errorf("NSX Policy NAT Rule path not set in resources ")
+	}
+	return path, nil
+}
+
+func TestValidatePolicyNATRuleTranslation(t *testing.T) {
+	cases := []struct {
+		action             string
+		translatedNetworks []string
+		translatedPorts    string
+		valid              bool
+	}{
+		{model.PolicyNatRule_ACTION_DNAT, []string{"16.1.1.3"}, "80", true},
+		{model.PolicyNatRule_ACTION_DNAT, nil, "", false},
+		{model.PolicyNatRule_ACTION_SNAT, []string{"16.1.1.3"}, "", true},
+		{model.PolicyNatRule_ACTION_SNAT, []string{"16.1.1.3"}, "80", false},
+		{model.PolicyNatRule_ACTION_REFLEXIVE, nil, "", false},
+		{model.PolicyNatRule_ACTION_NO_SNAT, nil, "", true},
+		{model.PolicyNatRule_ACTION_NO_DNAT, []string{"16.1.1.3"}, "", false},
+	}
+
+	for _, c := range cases {
+		err := validatePolicyNATRuleTranslation(c.action, c.translatedNetworks, c.translatedPorts)
+		if (err == nil) != c.valid {
+			t.Errorf("Expected valid %v for action %s with translated networks %v and ports '%s', got %v", c.valid, c.action, c.translatedNetworks, c.translatedPorts, err)
+		}
+	}
+}
+
+func TestResourceNsxtPolicyNATRuleImportByPath(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNsxtPolicyNATRule().Schema, map[string]interface{}{})
+	_, err := resourceNsxtPolicyNATRuleImportByPath(d, "/infra/tier-1s/gw1/nat/NAT64/nat-rules/rule1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d.Id() != "rule1" || d.Get("gateway_path").(string) != "/infra/tier-1s/gw1" || d.Get("action").(string) != model.PolicyNatRule_ACTION_NAT64 {
+		t.Errorf("Unexpected import result: id %s, gateway_path %s, action %s", d.Id(), d.Get("gateway_path"), d.Get("action"))
+	}
+
+	_, err = resourceNsxtPolicyNATRuleImportByPath(d, "/infra/tier-1s/gw1/segments/seg1")
+	if err == nil {
+		t.Errorf("Expected import of non NAT rule path to fail")
+	}
+}
+
 func testAccNsxtPolicyNATRuleExists(resourceName string, isNat bool) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
@@ -22,7 +22,7 @@ func resourceNsxtPolicySecurityPolicy() *schema.Resource {
 		Update: resourceNsxtPolicySecurityPolicyUpdate,
 		Delete: resourceNsxtPolicySecurityPolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: nsxtDomainResourceImporter,
+			State: nsxtDomainResourcePathImporter("security-policies"),
 		},
 		Schema: getPolicySecurityPolicySchema(false),
 	}
@@ -5,10 +5,12 @@ package nsxt
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
 
 func TestAccResourceNsxtPolicySecurityPolicy_basic(t *testing.T) {
@@ -391,6 +393,56 @@ func TestAccResourceNsxtPolicySecurityPolicy_importBasic(t *testing.T) {
 		},
 	})
 }
+func TestAccResourceNsxtPolicySecurityPolicy_importByPath(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_security_policy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicySecurityPolicyCheckDestroy(state, name, defaultDomain)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicySecurityPolicyBasic(name, "import", defaultDomain),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNSXPolicySecurityPolicyImporterGetPath,
+			},
+		},
+	})
+}
+
+func TestSortPolicyRulesBySequenceNumber(t *testing.T) {
+	newRule := func(id string, seq *int64) model.Rule {
+		return model.Rule{Id: &id, SequenceNumber: seq}
+	}
+	seq := func(n int64) *int64 { return &n }
+	rules := []model.Rule{
+		newRule("c", seq(2)),
+		newRule("none", nil),
+		newRule("a", seq(0)),
+		newRule("b", seq(1)),
+	}
+
+	sorted := sortPolicyRulesBySequenceNumber(rules)
+	var order []string
+	for _, rule := range sorted {
+		order = append(order, *rule.Id)
+	}
+	expected := "a,b,c,none"
+	if strings.Join(order, ",") != expected {
+		t.Errorf("Expected rule order %s, got %v", expected, order)
+	}
+	if *rules[0].Id != "c" {
+		t.Errorf("Expected original rules to be left intact")
+	}
+}
+
 func TestAccResourceNsxtGlobalPolicySecurityPolicy_withSite(t *testing.T) {
 	name := getAccTestResourceName()
 	updatedName := getAccTestResourceName()
@@ -841,3 +893,15 @@ profiles = [nsxt_policy_context_profile.test.path]
 `
 	return testAccNsxtPolicyContextProfileTemplate("security-policy-test-profile", testAccNsxtPolicyContextProfileAttributeDomainNameTemplate()) + testAccNsxtPolicySecurityPolicyWithRule(name, direction, protocol, ruleTag, domainName, profiles)
 }
+
+func testAccNSXPolicySecurityPolicyImporterGetPath(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["nsxt_policy_security_policy.test"]
+	if !ok {
+		return "", fmt.Errorf("NSX Policy Security Policy resource not found in resources")
+	}
+	path := rs.Primary.Attributes["path"]
+	if path == "" {
+		return "", fmt.Errorf("NSX Policy Security Policy path not set in resources")
+	}
+	return path, nil
+}
@@ -14,7 +14,7 @@ func resourceNsxtPolicySegment() *schema.Resource {
 		Update: resourceNsxtPolicySegmentUpdate,
 		Delete: resourceNsxtPolicySegmentDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: nsxtPolicyInfraSegmentImporter,
 		},
 
 		Schema: getPolicyCommonSegmentSchema(false, false),
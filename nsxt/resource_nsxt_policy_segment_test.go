@@ -35,6 +35,42 @@ func TestAccResourceNsxtPolicySegment_basicImport(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtPolicySegment_importByPath(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_segment.test"
+	tzName := getOverlayTransportZoneName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicySegmentCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicySegmentImportTemplate(tzName, name),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNSXPolicySegmentImporterGetPath,
+			},
+		},
+	})
+}
+
+func TestParseInfraSegmentImportPath(t *testing.T) {
+	isT0, gwID, segmentID := parseSegmentPolicyPath("/infra/segments/seg1")
+	if isT0 || gwID != "" || segmentID != "seg1" {
+		t.Errorf("Unexpected parse result for infra segment path: %v, %s, %s", isT0, gwID, segmentID)
+	}
+	_, gwID, segmentID = parseSegmentPolicyPath("/infra/tier-1s/t1/segments/seg1")
+	if gwID != "t1" || segmentID != "seg1" {
+		t.Errorf("Unexpected parse result for fixed segment path: %s, %s", gwID, segmentID)
+	}
+}
+
 func TestAccResourceNsxtPolicySegment_basicUpdate(t *testing.T) {
 	name := getAccTestResourceName()
 	updatedName := getAccTestResourceName()
@@ -724,3 +760,15 @@ resource "nsxt_policy_segment" "test" {
 }
 `, name, cidr)
 }
+
+func testAccNSXPolicySegmentImporterGetPath(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["nsxt_policy_segment.test"]
+	if !ok {
+		return "", fmt.Errorf("NSX Policy Segment resource not found in resources")
+	}
+	path := rs.Primary.Attributes["path"]
+	if path == "" {
+		return "", fmt.Errorf("NSX Policy Segment path not set in resources")
+	}
+	return path, nil
+}
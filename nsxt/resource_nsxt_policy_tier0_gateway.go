@@ -46,7 +46,7 @@ func resourceNsxtPolicyTier0Gateway() *schema.Resource {
 		Update: resourceNsxtPolicyTier0GatewayUpdate,
 		Delete: resourceNsxtPolicyTier0GatewayDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: nsxtPolicyGatewayImporter("tier-0s"),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -425,6 +426,75 @@ func TestAccResourceNsxtPolicyTier0Gateway_importBasic(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtPolicyTier0Gateway_importByPath(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_tier0_gateway.test"
+	failoverMode := "PREEMPTIVE"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccPreCheck(t); testAccNSXVersion(t, "3.0.0") },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyTier0CheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyTier0CreateTemplate(name, failoverMode),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNSXPolicyTier0ImporterGetPath,
+			},
+		},
+	})
+}
+
+func testAccNSXPolicyTier0ImporterGetPath(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["nsxt_policy_tier0_gateway.test"]
+	if !ok {
+		return "", fmt.Errorf("NSX Policy Tier0 resource not found in resources")
+	}
+	path := rs.Primary.Attributes["path"]
+	if path == "" {
+		return "", fmt.Errorf("NSX Policy Tier0 path not set in resources")
+	}
+	return path, nil
+}
+
+func TestNsxtPolicyGatewayImporter(t *testing.T) {
+	cases := []struct {
+		importID string
+		gwType   string
+		id       string
+		fail     bool
+	}{
+		{"gw1", "tier-0s", "gw1", false},
+		{"/infra/tier-0s/gw1", "tier-0s", "gw1", false},
+		{"/infra/tier-1s/gw1", "tier-1s", "gw1", false},
+		{"/infra/tier-1s/gw1", "tier-0s", "", true},
+		{"/infra/tier-0s/gw1/locale-services/default", "tier-0s", "", true},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceNsxtPolicyTier0Gateway().Schema, map[string]interface{}{})
+		d.SetId(c.importID)
+		_, err := nsxtPolicyGatewayImporter(c.gwType)(d, nil)
+		if c.fail {
+			if err == nil {
+				t.Errorf("Expected import of %s as %s to fail", c.importID, c.gwType)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for import of %s: %v", c.importID, err)
+		} else if d.Id() != c.id {
+			t.Errorf("Expected id %s for import of %s, got %s", c.id, c.importID, d.Id())
+		}
+	}
+}
+
 func testAccNsxtPolicyTier0Exists(resourceName string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 
@@ -0,0 +1,184 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func resourceNsxtPolicyTier0SecurityConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNsxtPolicyTier0SecurityConfigCreate,
+		Read:   resourceNsxtPolicyTier0SecurityConfigRead,
+		Update: resourceNsxtPolicyTier0SecurityConfigUpdate,
+		Delete: resourceNsxtPolicyTier0SecurityConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNsxtPolicyTier0SecurityConfigImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path":         getPathSchema(),
+			"display_name": getDisplayNameSchema(),
+			"description":  getDescriptionSchema(),
+			"revision":     getRevisionSchema(),
+			"tag":          getTagsSchema(),
+			"gateway_path": getPolicyPathSchema(true, true, "Policy path for the Tier0 Gateway"),
+			"idfw_enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable Identity Firewall on the Tier0 Gateway",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func getTier0SecurityConfigGatewayID(d *schema.ResourceData) (string, error) {
+	gwPolicyPath := d.Get("gateway_path").(string)
+	isT0, gwID := parseGatewayPolicyPath(gwPolicyPath)
+	if gwID == "" {
+		return "", fmt.Errorf("gateway_path is not valid")
+	}
+	if !isT0 {
+		return "", fmt.Errorf("Tier0 gateway path expected, got %s", gwPolicyPath)
+	}
+	return gwID, nil
+}
+
+func policyTier0SecurityConfigGet(connector *client.RestConnector, gwID string) (model.Tier0SecurityFeatures, error) {
+	client := tier_0s.NewSecurityConfigClient(connector)
+	// Only features managed by this resource are retrieved
+	feature := model.Tier0SecurityFeature_FEATURE_IDFW
+	return client.Get(gwID, nil, &feature, nil, nil, nil, nil)
+}
+
+func resourceNsxtPolicyTier0SecurityConfigRead(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	gwID, err := getTier0SecurityConfigGatewayID(d)
+	if err != nil {
+		return err
+	}
+
+	obj, err := policyTier0SecurityConfigGet(connector, gwID)
+	if err != nil {
+		return handleReadError(d, "Tier0 Security Config", gwID, err)
+	}
+
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setPolicyTagsInSchema(d, obj.Tags)
+	d.Set("path", obj.Path)
+	d.Set("revision", obj.Revision)
+
+	idfwEnabled := false
+	for _, feature := range obj.Features {
+		if feature.Feature != nil && *feature.Feature == model.Tier0SecurityFeature_FEATURE_IDFW && feature.Enable != nil {
+			idfwEnabled = *feature.Enable
+		}
+	}
+	d.Set("idfw_enabled", idfwEnabled)
+
+	return nil
+}
+
+func patchNsxtPolicyTier0SecurityConfig(connector *client.RestConnector, d *schema.ResourceData, gwID string) error {
+	displayName := d.Get("display_name").(string)
+	description := d.Get("description").(string)
+	tags := getPolicyTagsFromSchema(d)
+	idfwEnabled := d.Get("idfw_enabled").(bool)
+	idfwFeature := model.Tier0SecurityFeature_FEATURE_IDFW
+
+	obj := model.Tier0SecurityFeatures{
+		DisplayName: &displayName,
+		Description: &description,
+		Tags:        tags,
+		Features: []model.Tier0SecurityFeature{
+			{
+				Feature: &idfwFeature,
+				Enable:  &idfwEnabled,
+			},
+		},
+	}
+
+	client := tier_0s.NewSecurityConfigClient(connector)
+	_, err := client.Patch(gwID, obj)
+	return err
+}
+
+func resourceNsxtPolicyTier0SecurityConfigCreate(d *schema.ResourceData, m interface{}) error {
+	if isPolicyGlobalManager(m) {
+		return localManagerOnlyError()
+	}
+	connector := getPolicyConnector(m)
+
+	gwID, err := getTier0SecurityConfigGatewayID(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating Security Config for Tier0 Gateway %s", gwID)
+
+	err = patchNsxtPolicyTier0SecurityConfig(connector, d, gwID)
+	if err != nil {
+		return handleCreateError("Tier0 Security Config", gwID, err)
+	}
+
+	d.SetId(gwID)
+
+	return resourceNsxtPolicyTier0SecurityConfigRead(d, m)
+}
+
+func resourceNsxtPolicyTier0SecurityConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	gwID, err := getTier0SecurityConfigGatewayID(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating Security Config for Tier0 Gateway %s", gwID)
+	err = patchNsxtPolicyTier0SecurityConfig(connector, d, gwID)
+	if err != nil {
+		return handleUpdateError("Tier0 Security Config", gwID, err)
+	}
+
+	return resourceNsxtPolicyTier0SecurityConfigRead(d, m)
+}
+
+func resourceNsxtPolicyTier0SecurityConfigDelete(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+
+	gwID, err := getTier0SecurityConfigGatewayID(d)
+	if err != nil {
+		return err
+	}
+
+	client := tier_0s.NewSecurityConfigClient(connector)
+	err = client.Delete(gwID, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		return handleDeleteError("Tier0 Security Config", gwID, err)
+	}
+
+	return nil
+}
+
+func resourceNsxtPolicyTier0SecurityConfigImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	gwPath := d.Id()
+
+	isT0, gwID := parseGatewayPolicyPath(gwPath)
+	if gwID == "" || !isT0 {
+		return nil, fmt.Errorf("Tier0 gateway path expected for import, got %s", gwPath)
+	}
+	d.Set("gateway_path", gwPath)
+	d.SetId(gwID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,162 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func TestAccResourceNsxtPolicyTier0SecurityConfig_basic(t *testing.T) {
+	testResourceName := "nsxt_policy_tier0_security_config.test"
+	displayName := getAccTestResourceName()
+	description := "terraform created"
+	updatedDescription := "terraform updated"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t); testAccNSXVersion(t, "3.1.0") },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyTier0SecurityConfigCheckDestroy(state, displayName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyTier0SecurityConfigTemplate(displayName, description, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyTier0SecurityConfigExists(testResourceName, true),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", displayName),
+					resource.TestCheckResourceAttr(testResourceName, "description", description),
+					resource.TestCheckResourceAttr(testResourceName, "idfw_enabled", "true"),
+
+					resource.TestCheckResourceAttrSet(testResourceName, "gateway_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+				),
+			},
+			{
+				Config: testAccNsxtPolicyTier0SecurityConfigTemplate(displayName, updatedDescription, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNsxtPolicyTier0SecurityConfigExists(testResourceName, false),
+					resource.TestCheckResourceAttr(testResourceName, "display_name", displayName),
+					resource.TestCheckResourceAttr(testResourceName, "description", updatedDescription),
+					resource.TestCheckResourceAttr(testResourceName, "idfw_enabled", "false"),
+
+					resource.TestCheckResourceAttrSet(testResourceName, "gateway_path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "path"),
+					resource.TestCheckResourceAttrSet(testResourceName, "revision"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNsxtPolicyTier0SecurityConfig_importBasic(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_tier0_security_config.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccOnlyLocalManager(t); testAccNSXVersion(t, "3.1.0") },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyTier0SecurityConfigCheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyTier0SecurityConfigTemplate(name, "", true),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNSXPolicyTier0SecurityConfigIDGenerator(testResourceName),
+			},
+		},
+	})
+}
+
+func testAccNsxtPolicyTier0SecurityConfigIdfwEnabled(obj model.Tier0SecurityFeatures) bool {
+	for _, feature := range obj.Features {
+		if feature.Feature != nil && *feature.Feature == model.Tier0SecurityFeature_FEATURE_IDFW && feature.Enable != nil {
+			return *feature.Enable
+		}
+	}
+	return false
+}
+
+func testAccNsxtPolicyTier0SecurityConfigExists(resourceName string, idfwEnabled bool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Policy Tier0 Security Config resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("Policy Tier0 Security Config resource ID not set in resources")
+		}
+
+		obj, err := policyTier0SecurityConfigGet(connector, resourceID)
+		if err != nil {
+			return err
+		}
+
+		if testAccNsxtPolicyTier0SecurityConfigIdfwEnabled(obj) != idfwEnabled {
+			return fmt.Errorf("Policy Tier0 Security Config %s has IDFW enabled %v, expected %v", resourceID, !idfwEnabled, idfwEnabled)
+		}
+
+		return nil
+	}
+}
+
+func testAccNsxtPolicyTier0SecurityConfigCheckDestroy(state *terraform.State, displayName string) error {
+	connector := getPolicyConnector(testAccProvider.Meta().(nsxtClients))
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != "nsxt_policy_tier0_security_config" {
+			continue
+		}
+
+		// Security config is reset to defaults when deleted, and is gone
+		// altogether once the gateway is deleted
+		obj, err := policyTier0SecurityConfigGet(connector, rs.Primary.ID)
+		if err == nil && testAccNsxtPolicyTier0SecurityConfigIdfwEnabled(obj) {
+			return fmt.Errorf("Policy Tier0 Security Config %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func testAccNsxtPolicyTier0SecurityConfigTemplate(displayName string, description string, idfwEnabled bool) string {
+	return testAccNsxtPolicyEdgeClusterReadTemplate(getEdgeClusterName()) +
+		testAccNsxtPolicyTier0WithEdgeClusterTemplate("test", false) + fmt.Sprintf(`
+
+resource "nsxt_policy_tier0_security_config" "test" {
+  gateway_path = nsxt_policy_tier0_gateway.test.path
+  display_name = "%s"
+  description  = "%s"
+  idfw_enabled = %t
+}`, displayName, description, idfwEnabled)
+}
+
+func testAccNSXPolicyTier0SecurityConfigIDGenerator(testResourceName string) func(*terraform.State) (string, error) {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[testResourceName]
+		if !ok {
+			return "", fmt.Errorf("NSX Policy resource %s not found in resources", testResourceName)
+		}
+		gwPath := rs.Primary.Attributes["gateway_path"]
+		if gwPath == "" {
+			return "", fmt.Errorf("NSX Policy Gateway Path not set in resources ")
+		}
+
+		return gwPath, nil
+	}
+}
@@ -50,7 +50,7 @@ func resourceNsxtPolicyTier1Gateway() *schema.Resource {
 		Update: resourceNsxtPolicyTier1GatewayUpdate,
 		Delete: resourceNsxtPolicyTier1GatewayDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: nsxtPolicyGatewayImporter("tier-1s"),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -446,6 +446,43 @@ func TestAccResourceNsxtPolicyTier1Gateway_importBasic(t *testing.T) {
 	})
 }
 
+func TestAccResourceNsxtPolicyTier1Gateway_importByPath(t *testing.T) {
+	name := getAccTestResourceName()
+	testResourceName := "nsxt_policy_tier1_gateway.test"
+	failoverMode := "PREEMPTIVE"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccOnlyLocalManager(t); testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccNsxtPolicyTier1CheckDestroy(state, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsxtPolicyTier1ImportTemplate(name, failoverMode),
+			},
+			{
+				ResourceName:      testResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccNSXPolicyTier1ImporterGetPath,
+			},
+		},
+	})
+}
+
+func testAccNSXPolicyTier1ImporterGetPath(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["nsxt_policy_tier1_gateway.test"]
+	if !ok {
+		return "", fmt.Errorf("NSX Policy Tier1 resource not found in resources")
+	}
+	path := rs.Primary.Attributes["path"]
+	if path == "" {
+		return "", fmt.Errorf("NSX Policy Tier1 path not set in resources")
+	}
+	return path, nil
+}
+
 func testAccNsxtPolicyTier1Exists(resourceName string) resource.TestCheckFunc {
 	return testAccNsxtPolicyResourceExists(resourceName, resourceNsxtPolicyTier1GatewayExists)
 }
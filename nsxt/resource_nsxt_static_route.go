@@ -10,7 +10,6 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vmware-nsxt/common"
 	"github.com/vmware/go-vmware-nsxt/manager"
 )
@@ -30,12 +29,13 @@ func resourceNsxtStaticRoute() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "Logical router id",
 				Required:    true,
+				ForceNew:    true,
 			},
 			"network": {
 				Type:         schema.TypeString,
 				Description:  "CIDR",
 				Required:     true,
-				ValidateFunc: validation.IsCIDRNetwork(0, 32),
+				ValidateFunc: validateCidr(),
 			},
 			"next_hop": getNextHopsSchema(),
 			"revision": getRevisionSchema(),
@@ -59,6 +59,7 @@ func getNextHopsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
 		Required: true,
+		MinItems: 1,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"administrative_distance": {
@@ -101,9 +102,12 @@ func getNextHopsFromSchema(d *schema.ResourceData) []manager.StaticRouteNextHop
 		bfdEnabled := data["bfd_enabled"].(bool)
 		blackholeAction := data["blackhole_action"].(string)
 		ipAddress := data["ip_address"].(string)
-		logicalRouterPortID := &common.ResourceReference{
-			TargetType: "LogicalPort",
-			TargetId:   data["logical_router_port_id"].(string),
+		var logicalRouterPortID *common.ResourceReference
+		if portID := data["logical_router_port_id"].(string); portID != "" {
+			logicalRouterPortID = &common.ResourceReference{
+				TargetType: "LogicalPort",
+				TargetId:   portID,
+			}
 		}
 		elem := manager.StaticRouteNextHop{
 			AdministrativeDistance: administrativeDistance,
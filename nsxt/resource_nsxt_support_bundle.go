@@ -0,0 +1,466 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/supportbundle"
+)
+
+var supportBundleContentFilterValues = []string{"ALL", "DEFAULT", "IDS"}
+var supportBundleContainerTypeValues = []string{"CONTAINER_CLUSTER", "CONTAINER_NODE"}
+
+func resourceNsxtSupportBundle() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNsxtSupportBundleCreate,
+		Read:   resourceNsxtSupportBundleRead,
+		Delete: resourceNsxtSupportBundleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"nodes": {
+				Type:        schema.TypeList,
+				Description: "List of cluster/fabric node UUIDs to collect support bundles from. All management cluster and fabric nodes are used if not specified",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"container_node": getSupportBundleContainerNodeSchema(false),
+			"content_filters": {
+				Type:        schema.TypeList,
+				Description: "Bundle should include content of specified type",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(supportBundleContentFilterValues, false),
+				},
+			},
+			"log_age_limit": {
+				Type:         schema.TypeInt,
+				Description:  "Include log files with modified times not past the age limit in days",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"remote_file_server": {
+				Type:        schema.TypeList,
+				Description: "Remote file server to copy the bundles to",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server": {
+							Type:        schema.TypeString,
+							Description: "Remote server hostname or IP address",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Description:  "Server port",
+							Optional:     true,
+							ForceNew:     true,
+							Default:      22,
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"directory_path": {
+							Type:        schema.TypeString,
+							Description: "Remote server directory to copy bundle files to",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Description: "User name to authenticate with",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Description: "Password to authenticate with. Only used upon collection, and not kept in state",
+							Required:    true,
+							Sensitive:   true,
+							// Password is cleared from state after collection
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return d.Id() != ""
+							},
+						},
+						"ssh_fingerprint": {
+							Type:        schema.TypeString,
+							Description: "SSH fingerprint of server",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"manager_upload_only": {
+							Type:        schema.TypeBool,
+							Description: "Uploads to the remote file server performed by the manager",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+			"success_node": {
+				Type:        schema.TypeList,
+				Description: "Nodes whose bundles were successfully copied to remote file server",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"node_id":           getSupportBundleNodeIDSchema(),
+						"node_display_name": getSupportBundleNodeDisplayNameSchema(),
+						"bundle_name": {
+							Type:        schema.TypeString,
+							Description: "Name of support bundle",
+							Computed:    true,
+						},
+						"bundle_size": {
+							Type:        schema.TypeInt,
+							Description: "Size of support bundle in bytes",
+							Computed:    true,
+						},
+						"sha256_thumbprint": {
+							Type:        schema.TypeString,
+							Description: "SHA256 thumbprint of the bundle file",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"failed_node": {
+				Type:        schema.TypeList,
+				Description: "Nodes where bundles were not generated or not copied to remote server",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"node_id":           getSupportBundleNodeIDSchema(),
+						"node_display_name": getSupportBundleNodeDisplayNameSchema(),
+						"error_code": {
+							Type:        schema.TypeString,
+							Description: "Error code",
+							Computed:    true,
+						},
+						"error_message": {
+							Type:        schema.TypeString,
+							Description: "Error message",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"remaining_node": {
+				Type:        schema.TypeList,
+				Description: "Nodes where bundle generation is pending or in progress",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"node_id":           getSupportBundleNodeIDSchema(),
+						"node_display_name": getSupportBundleNodeDisplayNameSchema(),
+						"status": {
+							Type:        schema.TypeString,
+							Description: "Status of node",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"requested_container_node": getSupportBundleContainerNodeSchema(true),
+		},
+	}
+}
+
+func getSupportBundleNodeIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "UUID of node",
+		Computed:    true,
+	}
+}
+
+func getSupportBundleNodeDisplayNameSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Display name of node",
+		Computed:    true,
+	}
+}
+
+func getSupportBundleContainerNodeSchema(computed bool) *schema.Schema {
+	containerNodeSchema := &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Container clusters and their nodes requiring support bundle collection",
+		Optional:    !computed,
+		Computed:    computed,
+		ForceNew:    !computed,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"container_type": {
+					Type:        schema.TypeString,
+					Description: "Support bundle container type",
+					Required:    !computed,
+					Computed:    computed,
+					ForceNew:    !computed,
+				},
+				"cluster": {
+					Type:        schema.TypeList,
+					Description: "Container clusters and their nodes",
+					Optional:    !computed,
+					Computed:    computed,
+					ForceNew:    !computed,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"cluster_id": {
+								Type:        schema.TypeString,
+								Description: "UUID of the container cluster",
+								Required:    !computed,
+								Computed:    computed,
+								ForceNew:    !computed,
+							},
+							"nodes": {
+								Type:        schema.TypeList,
+								Description: "List of container node UUIDs requiring a support bundle",
+								Optional:    !computed,
+								Computed:    computed,
+								ForceNew:    !computed,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if !computed {
+		containerTypeSchema := containerNodeSchema.Elem.(*schema.Resource).Schema["container_type"]
+		containerTypeSchema.ValidateFunc = validation.StringInSlice(supportBundleContainerTypeValues, false)
+	}
+
+	return containerNodeSchema
+}
+
+func getSupportBundleContainerNodesFromSchema(d *schema.ResourceData) []supportbundle.SupportBundleContainerNode {
+	var containerNodes []supportbundle.SupportBundleContainerNode
+	for _, containerNode := range d.Get("container_node").([]interface{}) {
+		data := containerNode.(map[string]interface{})
+		var clusters []supportbundle.ContainerClusterNode
+		for _, cluster := range data["cluster"].([]interface{}) {
+			clusterData := cluster.(map[string]interface{})
+			clusters = append(clusters, supportbundle.ContainerClusterNode{
+				ClusterId: clusterData["cluster_id"].(string),
+				Nodes:     interface2StringList(clusterData["nodes"].([]interface{})),
+			})
+		}
+		containerNodes = append(containerNodes, supportbundle.SupportBundleContainerNode{
+			ContainerType: data["container_type"].(string),
+			Clusters:      clusters,
+		})
+	}
+	return containerNodes
+}
+
+func setSupportBundleContainerNodesInSchema(d *schema.ResourceData, containerNodes []supportbundle.SupportBundleContainerNode) error {
+	var containerNodeList []map[string]interface{}
+	for _, containerNode := range containerNodes {
+		var clusterList []map[string]interface{}
+		for _, cluster := range containerNode.Clusters {
+			elem := make(map[string]interface{})
+			elem["cluster_id"] = cluster.ClusterId
+			elem["nodes"] = cluster.Nodes
+			clusterList = append(clusterList, elem)
+		}
+		elem := make(map[string]interface{})
+		elem["container_type"] = containerNode.ContainerType
+		elem["cluster"] = clusterList
+		containerNodeList = append(containerNodeList, elem)
+	}
+	return d.Set("requested_container_node", containerNodeList)
+}
+
+func getSupportBundleRemoteFileServerFromSchema(d *schema.ResourceData) *supportbundle.SupportBundleRemoteFileServer {
+	servers := d.Get("remote_file_server").([]interface{})
+	if len(servers) == 0 {
+		return nil
+	}
+	data := servers[0].(map[string]interface{})
+	return &supportbundle.SupportBundleRemoteFileServer{
+		Server:            data["server"].(string),
+		Port:              int64(data["port"].(int)),
+		DirectoryPath:     data["directory_path"].(string),
+		ManagerUploadOnly: data["manager_upload_only"].(bool),
+		Protocol: &supportbundle.SupportBundleFileTransferProtocol{
+			Name:           "SCP",
+			SshFingerprint: data["ssh_fingerprint"].(string),
+			AuthenticationScheme: &supportbundle.SupportBundleFileTransferAuthenticationScheme{
+				SchemeName: "PASSWORD",
+				Username:   data["username"].(string),
+				Password:   data["password"].(string),
+			},
+		},
+	}
+}
+
+// clearSupportBundleRemoteFileServerPassword removes the password from state,
+// since it is only needed upon collection
+func clearSupportBundleRemoteFileServerPassword(d *schema.ResourceData) error {
+	var servers []map[string]interface{}
+	for _, server := range d.Get("remote_file_server").([]interface{}) {
+		data := server.(map[string]interface{})
+		elem := make(map[string]interface{})
+		for key, value := range data {
+			elem[key] = value
+		}
+		elem["password"] = ""
+		servers = append(servers, elem)
+	}
+	return d.Set("remote_file_server", servers)
+}
+
+// listSupportBundleDefaultNodes returns all management cluster and fabric nodes
+func listSupportBundleDefaultNodes(nsxClient *api.APIClient) ([]string, error) {
+	var nodes []string
+	clusterLister := func(info *paginationInfo) error {
+		objList, _, err := nsxClient.NsxComponentAdministrationApi.ListClusterNodeConfigs(nsxClient.Context, info.LocalVarOptionals)
+		if err != nil {
+			return fmt.Errorf("Error while reading cluster node configuration: %v", err)
+		}
+
+		info.PageCount = int64(len(objList.Results))
+		info.TotalCount = objList.ResultCount
+		info.Cursor = objList.Cursor
+
+		for _, node := range objList.Results {
+			nodes = append(nodes, node.Id)
+		}
+		return nil
+	}
+
+	_, err := handlePagination(clusterLister)
+	if err != nil {
+		return nil, err
+	}
+
+	lister := func(info *paginationInfo) error {
+		objList, _, err := nsxClient.FabricApi.ListNodes(nsxClient.Context, info.LocalVarOptionals)
+		if err != nil {
+			return fmt.Errorf("Error while reading fabric nodes: %v", err)
+		}
+
+		info.PageCount = int64(len(objList.Results))
+		info.TotalCount = objList.ResultCount
+		info.Cursor = objList.Cursor
+
+		for _, node := range objList.Results {
+			nodes = append(nodes, node.Id)
+		}
+		return nil
+	}
+
+	_, err = handlePagination(lister)
+	return nodes, err
+}
+
+func resourceNsxtSupportBundleCreate(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(nsxtClients).NsxtClient
+	if nsxClient == nil {
+		return resourceNotSupportedError()
+	}
+
+	nodes := interface2StringList(d.Get("nodes").([]interface{}))
+	if len(nodes) == 0 {
+		defaultNodes, err := listSupportBundleDefaultNodes(nsxClient)
+		if err != nil {
+			return err
+		}
+		nodes = defaultNodes
+	}
+
+	request := supportbundle.SupportBundleRequest{
+		Nodes:            nodes,
+		ContainerNodes:   getSupportBundleContainerNodesFromSchema(d),
+		ContentFilters:   interface2StringList(d.Get("content_filters").([]interface{})),
+		LogAgeLimit:      int64(d.Get("log_age_limit").(int)),
+		RemoteFileServer: getSupportBundleRemoteFileServerFromSchema(d),
+	}
+
+	log.Printf("[INFO] Collecting support bundles from %d nodes", len(nodes))
+	result, resp, err := nsxClient.SupportBundleApi.CollectSupportBundlesCollect(nsxClient.Context, request, nil)
+	if err != nil {
+		return fmt.Errorf("Error while collecting support bundles: %v", err)
+	}
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Unexpected Response while collecting support bundles. Status Code: %d", resp.StatusCode)
+	}
+
+	var successList []map[string]interface{}
+	for _, node := range result.SuccessNodes {
+		elem := make(map[string]interface{})
+		elem["node_id"] = node.NodeId
+		elem["node_display_name"] = node.NodeDisplayName
+		elem["bundle_name"] = node.BundleName
+		elem["bundle_size"] = node.BundleSize
+		elem["sha256_thumbprint"] = node.Sha256Thumbprint
+		successList = append(successList, elem)
+	}
+	d.Set("success_node", successList)
+
+	var failedList []map[string]interface{}
+	for _, node := range result.FailedNodes {
+		elem := make(map[string]interface{})
+		elem["node_id"] = node.NodeId
+		elem["node_display_name"] = node.NodeDisplayName
+		elem["error_code"] = node.ErrorCode
+		elem["error_message"] = node.ErrorMessage
+		failedList = append(failedList, elem)
+	}
+	d.Set("failed_node", failedList)
+
+	var remainingList []map[string]interface{}
+	for _, node := range result.RemainingNodes {
+		elem := make(map[string]interface{})
+		elem["node_id"] = node.NodeId
+		elem["node_display_name"] = node.NodeDisplayName
+		elem["status"] = node.Status
+		remainingList = append(remainingList, elem)
+	}
+	d.Set("remaining_node", remainingList)
+
+	containerNodes := request.ContainerNodes
+	if result.RequestProperties != nil {
+		containerNodes = result.RequestProperties.ContainerNodes
+	}
+	err = setSupportBundleContainerNodesInSchema(d, containerNodes)
+	if err != nil {
+		return fmt.Errorf("Error while setting container nodes: %v", err)
+	}
+
+	d.SetId(newUUID())
+	return clearSupportBundleRemoteFileServerPassword(d)
+}
+
+// Support bundle collection is a one-time action with side effects on the
+// remote file server, hence it is modeled as a resource rather than a data
+// source, which would trigger collection upon every refresh.
+// NSX does not keep the collection result, so there is nothing to read back
+// and nothing to delete.
+func resourceNsxtSupportBundleRead(d *schema.ResourceData, m interface{}) error {
+	// Collection result is only available upon collection, and kept in state
+	return nil
+}
+
+func resourceNsxtSupportBundleDelete(d *schema.ResourceData, m interface{}) error {
+	// Collected bundles are left on the remote file server
+	return nil
+}
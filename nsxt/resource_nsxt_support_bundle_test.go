@@ -0,0 +1,111 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceNsxtSupportBundle_basic(t *testing.T) {
+	testResourceName := "nsxt_support_bundle.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccOnlyLocalManager(t)
+			testAccTestMP(t)
+			testAccPreCheck(t)
+			testAccEnvDefined(t, "NSXT_TEST_SUPPORT_BUNDLE_SERVER")
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNSXSupportBundleCreateTemplate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testResourceName, "id"),
+					resource.TestCheckResourceAttr(testResourceName, "failed_node.#", "0"),
+					resource.TestCheckResourceAttr(testResourceName, "remote_file_server.0.password", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestClearSupportBundleRemoteFileServerPassword(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNsxtSupportBundle().Schema, map[string]interface{}{
+		"remote_file_server": []interface{}{map[string]interface{}{
+			"server":          "10.0.0.15",
+			"directory_path":  "/bundles",
+			"username":        "admin",
+			"password":        "secret",
+			"ssh_fingerprint": "SHA256:fingerprint",
+		}},
+	})
+	if err := clearSupportBundleRemoteFileServerPassword(d); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if password := d.Get("remote_file_server.0.password").(string); password != "" {
+		t.Errorf("Expected password to be cleared, got %s", password)
+	}
+	if server := d.Get("remote_file_server.0.server").(string); server != "10.0.0.15" {
+		t.Errorf("Expected server to be kept, got %s", server)
+	}
+}
+
+func TestSupportBundleRemoteFileServerPort(t *testing.T) {
+	remoteFileServer := map[string]interface{}{
+		"server":          "10.0.0.15",
+		"port":            2222,
+		"directory_path":  "/bundles",
+		"username":        "admin",
+		"password":        "secret",
+		"ssh_fingerprint": "SHA256:fingerprint",
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"remote_file_server": []interface{}{remoteFileServer},
+	})
+	if diags := resourceNsxtSupportBundle().Validate(config); diags.HasError() {
+		t.Fatalf("Unexpected validation error: %v", diags)
+	}
+
+	remoteFileServer["port"] = 70000
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"remote_file_server": []interface{}{remoteFileServer},
+	})
+	if diags := resourceNsxtSupportBundle().Validate(config); !diags.HasError() {
+		t.Errorf("Expected validation error for port 70000")
+	}
+
+	remoteFileServer["port"] = 2222
+	d := schema.TestResourceDataRaw(t, resourceNsxtSupportBundle().Schema, map[string]interface{}{
+		"remote_file_server": []interface{}{remoteFileServer},
+	})
+	server := getSupportBundleRemoteFileServerFromSchema(d)
+	if server.Port != 2222 {
+		t.Errorf("Expected port 2222, got %d", server.Port)
+	}
+}
+
+func testAccNSXSupportBundleCreateTemplate() string {
+	return fmt.Sprintf(`
+resource "nsxt_support_bundle" "test" {
+  content_filters = ["DEFAULT"]
+  log_age_limit   = 1
+
+  remote_file_server {
+    server          = "%s"
+    directory_path  = "%s"
+    username        = "%s"
+    password        = "%s"
+    ssh_fingerprint = "%s"
+  }
+}`, os.Getenv("NSXT_TEST_SUPPORT_BUNDLE_SERVER"), os.Getenv("NSXT_TEST_SUPPORT_BUNDLE_DIRECTORY"),
+		os.Getenv("NSXT_TEST_SUPPORT_BUNDLE_USERNAME"), os.Getenv("NSXT_TEST_SUPPORT_BUNDLE_PASSWORD"),
+		os.Getenv("NSXT_TEST_SUPPORT_BUNDLE_FINGERPRINT"))
+}
@@ -1564,3 +1564,23 @@ func parseSegmentPolicyPath(path string) (bool, string, string) {
 	isT0, gwID := parseGatewayPolicyPath(gwPath)
 	return isT0, gwID, segmentID
 }
+
+// nsxtPolicyInfraSegmentImporter accepts either segment ID or policy path
+// of infra segment as import ID
+func nsxtPolicyInfraSegmentImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	if !isPolicyPath(importID) {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	_, gwID, segmentID := parseSegmentPolicyPath(importID)
+	if segmentID == "" {
+		return nil, fmt.Errorf("Import path %s is not a segment path", importID)
+	}
+	if gwID != "" {
+		return nil, fmt.Errorf("Import path %s belongs to a fixed segment, please use nsxt_policy_fixed_segment resource", importID)
+	}
+	d.SetId(segmentID)
+
+	return []*schema.ResourceData{d}, nil
+}
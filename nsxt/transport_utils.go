@@ -0,0 +1,327 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// requestLimiter is a token bucket allowing a steady rate of requests per
+// second, with bursts of up to one second worth of requests
+type requestLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRequestLimiter(requestsPerSecond int) *requestLimiter {
+	rate := float64(requestsPerSecond)
+	return &requestLimiter{
+		rate:   rate,
+		burst:  rate,
+		tokens: rate,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket, and returns how long the caller
+// should wait before the token becomes available
+func (l *requestLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// rateLimitedTransport delays requests so that they do not exceed the rate
+// allowed by the limiter, which may be shared between several clients
+type rateLimitedTransport struct {
+	transport http.RoundTripper
+	limiter   *requestLimiter
+}
+
+func newRateLimitedTransport(transport http.RoundTripper, limiter *requestLimiter) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &rateLimitedTransport{transport: transport, limiter: limiter}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if delay := t.limiter.reserve(); delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// userAgentTransport sets User-Agent header on all requests
+type userAgentTransport struct {
+	transport http.RoundTripper
+	userAgent string
+}
+
+func newUserAgentTransport(transport http.RoundTripper, userAgent string) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &userAgentTransport{transport: transport, userAgent: userAgent}
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Round trippers should not modify the original request
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.transport.RoundTrip(req)
+}
+
+// getUserAgent returns User-Agent identifying the provider and its version,
+// followed by optional custom suffix
+func getUserAgent(suffix string) string {
+	version := ProviderVersion
+	if version == "" {
+		version = "dev"
+	}
+	userAgent := fmt.Sprintf("terraform-provider-nsxt/%s", version)
+	if suffix != "" {
+		userAgent = fmt.Sprintf("%s (%s)", userAgent, suffix)
+	}
+	return userAgent
+}
+
+// NSX error codes of 403 responses that indicate the session is no longer
+// valid, as opposed to the user lacking permissions: bad XSRF token, and
+// missing or expired session
+var sessionExpiredErrorCodes = []int64{98, 403}
+
+// sessionTransport authenticates requests with an NSX session (JSESSIONID
+// cookie and XSRF token) instead of basic auth, creating the session on first
+// use and re-creating it once the session expires. Rejection of credentials
+// upon session creation is returned for all further requests, in order not to
+// lock the account by repeated logins with wrong credentials. Other failures,
+// such as connection errors or server errors, are not kept, and the next
+// request attempts to create the session again.
+type sessionTransport struct {
+	transport http.RoundTripper
+	loginURL  string
+	username  string
+	password  string
+	mutex     sync.Mutex
+	cookie    string
+	xsrfToken string
+	loginErr  error
+}
+
+func newSessionTransport(transport http.RoundTripper, loginURL string, username string, password string) *sessionTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &sessionTransport{
+		transport: transport,
+		loginURL:  loginURL,
+		username:  username,
+		password:  password,
+	}
+}
+
+// login creates a new session, should be called with the mutex held. Returned
+// flag indicates whether the failure is due to rejected credentials.
+func (t *sessionTransport) login() (bool, error) {
+	form := url.Values{}
+	form.Set("j_username", t.username)
+	form.Set("j_password", t.password)
+	req, err := http.NewRequest("POST", t.loginURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return true, fmt.Errorf("credentials rejected with status code %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("status code %d", resp.StatusCode)
+	}
+
+	t.cookie = ""
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "JSESSIONID" {
+			t.cookie = cookie.Name + "=" + cookie.Value
+		}
+	}
+	if t.cookie == "" {
+		return false, fmt.Errorf("no session cookie in response")
+	}
+	t.xsrfToken = resp.Header.Get("X-XSRF-TOKEN")
+	return false, nil
+}
+
+// getSession returns the current session, creating it if needed. If expired is
+// set, the session is re-created unless this was already done by another request.
+func (t *sessionTransport) getSession(expired string) (string, string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.loginErr != nil {
+		return "", "", t.loginErr
+	}
+	if t.cookie == "" || t.cookie == expired {
+		rejected, err := t.login()
+		if err != nil {
+			t.cookie = ""
+			err = fmt.Errorf("Failed to create NSX session: %v", err)
+			if rejected {
+				t.loginErr = err
+			}
+			return "", "", err
+		}
+	}
+	return t.cookie, t.xsrfToken, nil
+}
+
+func (t *sessionTransport) withSession(req *http.Request, cookie string, xsrfToken string) (*http.Request, error) {
+	sessionReq := req.Clone(req.Context())
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		sessionReq.Body = body
+	}
+	sessionReq.Header.Set("Cookie", cookie)
+	if xsrfToken != "" {
+		sessionReq.Header.Set("X-XSRF-TOKEN", xsrfToken)
+	}
+	// Session replaces basic auth
+	if strings.HasPrefix(sessionReq.Header.Get("Authorization"), "Basic ") {
+		sessionReq.Header.Del("Authorization")
+	}
+	return sessionReq, nil
+}
+
+// isSessionExpiredResponse returns whether the response rejects the session
+// rather than the operation. Response body is kept readable for the caller.
+func isSessionExpiredResponse(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden || resp.Body == nil {
+		return false
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	var apiError managerAPIError
+	if json.Unmarshal(body, &apiError) != nil {
+		return false
+	}
+	for _, code := range sessionExpiredErrorCodes {
+		if apiError.ErrorCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.GetBody == nil {
+		// The request can not be replayed, hence fall back to basic auth
+		return t.transport.RoundTrip(req)
+	}
+
+	cookie, xsrfToken, err := t.getSession("")
+	if err != nil {
+		return nil, err
+	}
+
+	sessionReq, err := t.withSession(req, cookie, xsrfToken)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.transport.RoundTrip(sessionReq)
+	if err != nil || !isSessionExpiredResponse(resp) {
+		return resp, err
+	}
+
+	// Re-create the expired session and retry once
+	log.Printf("[DEBUG] NSX session expired on request %s %s, re-creating it", req.Method, req.URL)
+	resp.Body.Close()
+	newCookie, newXsrfToken, err := t.getSession(cookie)
+	if err != nil {
+		return nil, err
+	}
+	sessionReq, err = t.withSession(req, newCookie, newXsrfToken)
+	if err != nil {
+		return nil, err
+	}
+	return t.transport.RoundTrip(sessionReq)
+}
+
+// Headers that carry credentials or session tokens, and should never be logged
+var redactedLogHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-XSRF-TOKEN"}
+
+func redactLogHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedLogHeaders {
+		if _, ok := redacted[http.CanonicalHeaderKey(name)]; ok {
+			redacted.Set(name, "<redacted>")
+		}
+	}
+	return redacted
+}
+
+// loggingTransport logs method, URL, status and headers of requests, with
+// credentials redacted. Request and response bodies are not logged.
+type loggingTransport struct {
+	transport http.RoundTripper
+}
+
+func newLoggingTransport(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &loggingTransport{transport: transport}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	log.Printf("[DEBUG] NSX API request %s %s, headers: %v", req.Method, req.URL, redactLogHeaders(req.Header))
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		log.Printf("[DEBUG] NSX API request %s %s failed: %v", req.Method, req.URL, err)
+		return resp, err
+	}
+	log.Printf("[DEBUG] NSX API response for %s %s: %s, headers: %v", req.Method, req.URL, resp.Status, redactLogHeaders(resp.Header))
+	return resp, nil
+}
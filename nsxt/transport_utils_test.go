@@ -0,0 +1,249 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	count := 0
+	transport := newRateLimitedTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		count++
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}), newRequestLimiter(50))
+
+	// The first second worth of requests is allowed as a burst, the rest
+	// should be delayed according to the rate
+	start := time.Now()
+	for i := 0; i < 75; i++ {
+		req, _ := http.NewRequest("GET", "https://nsx/api/v1/node", nil)
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+	if count != 75 {
+		t.Errorf("Expected 75 requests to be sent, got %d", count)
+	}
+	if elapsed < 400*time.Millisecond {
+		t.Errorf("Expected requests above the burst to be delayed, all were sent in %v", elapsed)
+	}
+
+	// A cancelled request is not sent while waiting for a token
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequest("GET", "https://nsx/api/v1/node", nil)
+	if _, err := transport.RoundTrip(req.WithContext(ctx)); err == nil {
+		t.Errorf("Expected cancelled request to fail")
+	}
+	if count != 75 {
+		t.Errorf("Expected cancelled request not to be sent")
+	}
+}
+
+func TestSessionTransport(t *testing.T) {
+	logins := 0
+	currentSession := ""
+	loginStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/session/create" {
+			logins++
+			if loginStatus != http.StatusOK {
+				w.WriteHeader(loginStatus)
+				return
+			}
+			currentSession = "session" + strings.Repeat("x", logins)
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: currentSession})
+			w.Header().Set("X-XSRF-TOKEN", "token")
+			return
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Expected basic auth to be replaced by session")
+		}
+		cookie, err := r.Cookie("JSESSIONID")
+		if err != nil || cookie.Value != currentSession || r.Header.Get("X-XSRF-TOKEN") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error_code": 403, "error_message": "The credentials were incorrect or the account specified has been locked."}`))
+			return
+		}
+		if r.URL.Path == "/api/v1/forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error_code": 401, "error_message": "The requested operation is not permitted"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newSessionTransport(http.DefaultTransport, server.URL+"/api/session/create", "admin", "password")
+	send := func(path string) (int, error) {
+		req, _ := http.NewRequest("PUT", server.URL+path, strings.NewReader("{}"))
+		req.SetBasicAuth("admin", "password")
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	// Session is created once and reused
+	for i := 0; i < 3; i++ {
+		if status, err := send("/api/v1/ns-services/1"); err != nil || status != http.StatusOK {
+			t.Errorf("Expected status 200, got %d, %v", status, err)
+		}
+	}
+	if logins != 1 {
+		t.Errorf("Expected a single login, got %d", logins)
+	}
+
+	// Permission denial is returned as is, without re-creating the session
+	if status, err := send("/api/v1/forbidden"); err != nil || status != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d, %v", status, err)
+	}
+	if logins != 1 {
+		t.Errorf("Expected session not to be re-created on permission denial, got %d logins", logins)
+	}
+
+	// Expired session is re-created transparently
+	currentSession = "expired"
+	if status, err := send("/api/v1/ns-services/1"); err != nil || status != http.StatusOK {
+		t.Errorf("Expected status 200 after session renewal, got %d, %v", status, err)
+	}
+	if logins != 2 {
+		t.Errorf("Expected session to be re-created, got %d logins", logins)
+	}
+
+	// Transient login failure is reported, and retried on further requests
+	currentSession = "expired"
+	loginStatus = http.StatusServiceUnavailable
+	if _, err := send("/api/v1/ns-services/1"); err == nil {
+		t.Errorf("Expected login failure to be reported")
+	}
+	if logins != 3 {
+		t.Errorf("Expected a single failed login, got %d logins", logins-2)
+	}
+	loginStatus = http.StatusOK
+	if status, err := send("/api/v1/ns-services/1"); err != nil || status != http.StatusOK {
+		t.Errorf("Expected status 200 after transient login failure, got %d, %v", status, err)
+	}
+	if logins != 4 {
+		t.Errorf("Expected login to be retried after transient failure, got %d logins", logins)
+	}
+
+	// Rejected credentials are reported, and not retried on further requests
+	currentSession = "expired"
+	loginStatus = http.StatusForbidden
+	for i := 0; i < 3; i++ {
+		if _, err := send("/api/v1/ns-services/1"); err == nil {
+			t.Errorf("Expected login failure to be reported")
+		}
+	}
+	if logins != 5 {
+		t.Errorf("Expected a single rejected login, got %d logins", logins-4)
+	}
+}
+
+func TestSessionTransportConnectionError(t *testing.T) {
+	logins := 0
+	connectionFails := true
+	transport := newSessionTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/api/session/create" {
+			logins++
+			if connectionFails {
+				return nil, fmt.Errorf("connection reset by peer")
+			}
+			header := http.Header{}
+			header.Add("Set-Cookie", "JSESSIONID=session")
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}), "https://nsx/api/session/create", "admin", "password")
+
+	req, _ := http.NewRequest("GET", "https://nsx/api/v1/ns-services/1", nil)
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Errorf("Expected connection error to be reported")
+	}
+	connectionFails = false
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected request to succeed after connection error, got %v", err)
+	}
+	if logins != 2 {
+		t.Errorf("Expected login to be retried after connection error, got %d logins", logins)
+	}
+}
+
+func TestLoggingTransport(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	transport := newLoggingTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("Set-Cookie", "JSESSIONID=secret-session")
+		return &http.Response{Status: "200 OK", StatusCode: http.StatusOK, Header: header}, nil
+	}))
+
+	req, _ := http.NewRequest("GET", "https://nsx/api/v1/firewall/sections", nil)
+	req.SetBasicAuth("admin", "secret-password")
+	req.Header.Set("Cookie", "JSESSIONID=secret-session")
+	req.Header.Set("X-XSRF-TOKEN", "secret-token")
+	_, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "secret") || strings.Contains(output, "YWRtaW46") {
+		t.Errorf("Credentials found in log output: %s", output)
+	}
+	if !strings.Contains(output, "GET https://nsx/api/v1/firewall/sections") || !strings.Contains(output, "200 OK") {
+		t.Errorf("Request details not found in log output: %s", output)
+	}
+	if req.Header.Get("X-XSRF-TOKEN") != "secret-token" {
+		t.Errorf("Request headers should not be modified")
+	}
+}
+
+func TestUserAgentTransport(t *testing.T) {
+	var userAgent string
+	transport := newUserAgentTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		userAgent = req.Header.Get("User-Agent")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}), getUserAgent("team-a"))
+
+	req, _ := http.NewRequest("GET", "https://nsx/policy/api/v1/infra", nil)
+	req.Header.Set("User-Agent", "vAPI/2.100.0 Go/go1.16")
+	_, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if userAgent != "terraform-provider-nsxt/dev (team-a)" {
+		t.Errorf("Unexpected User-Agent header: %s", userAgent)
+	}
+	if req.Header.Get("User-Agent") != "vAPI/2.100.0 Go/go1.16" {
+		t.Errorf("Request headers should not be modified")
+	}
+	if getUserAgent("") != "terraform-provider-nsxt/dev" {
+		t.Errorf("Unexpected User-Agent without suffix: %s", getUserAgent(""))
+	}
+}
@@ -5,12 +5,15 @@ package nsxt
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"hash/crc32"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	api "github.com/vmware/go-vmware-nsxt"
@@ -21,6 +24,22 @@ import (
 )
 
 var adminStateValues = []string{"UP", "DOWN"}
+
+// resourceReferenceTargetTypes lists target types of resource references
+// in the casing expected by NSX
+var resourceReferenceTargetTypes = []string{
+	"DhcpRelayService",
+	"IPSet",
+	"LogicalPort",
+	"LogicalRouter",
+	"LogicalRouterPort",
+	"LogicalService",
+	"LogicalSwitch",
+	"MACSet",
+	"NSGroup",
+	"NSService",
+	"NSServiceGroup",
+}
 var nsxVersion = ""
 
 func interface2StringList(configured []interface{}) []string {
@@ -158,7 +177,158 @@ func setTagsInSchema(d *schema.ResourceData, tags []common.Tag) {
 	setCustomizedTagsInSchema(d, tags, "tag")
 }
 
+func getTagMergeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Only manage tags with scopes specified in configuration, and preserve tags applied outside of terraform",
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+func getTagScopesFromList(tags []interface{}) map[string]bool {
+	scopes := make(map[string]bool)
+	for _, tag := range tags {
+		data, ok := tag.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		scope, _ := data["scope"].(string)
+		scopes[scope] = true
+	}
+	return scopes
+}
+
+// getManagedTagScopes returns the scopes owned by terraform, which are scopes
+// present in previous state or in current configuration. Scopes removed from
+// configuration are still owned, so that their tags are removed on update.
+func getManagedTagScopes(d *schema.ResourceData) map[string]bool {
+	oldTags, newTags := d.GetChange("tag")
+	scopes := getTagScopesFromList(oldTags.(*schema.Set).List())
+	for scope := range getTagScopesFromList(newTags.(*schema.Set).List()) {
+		scopes[scope] = true
+	}
+	return scopes
+}
+
+// mergeTags keeps existing tags with scopes not owned by terraform, and
+// replaces the rest with configured tags
+func mergeTags(existingTags []common.Tag, configuredTags []common.Tag, managedScopes map[string]bool) []common.Tag {
+	tagList := make([]common.Tag, 0, len(existingTags)+len(configuredTags))
+	for _, tag := range existingTags {
+		if !managedScopes[tag.Scope] {
+			tagList = append(tagList, tag)
+		}
+	}
+	return append(tagList, configuredTags...)
+}
+
+// filterManagedTags returns tags with scopes owned by terraform
+func filterManagedTags(tags []common.Tag, managedScopes map[string]bool) []common.Tag {
+	var tagList []common.Tag
+	for _, tag := range tags {
+		if managedScopes[tag.Scope] {
+			tagList = append(tagList, tag)
+		}
+	}
+	return tagList
+}
+
+// getMergedTagsFromSchema returns the tags to be sent on update. With tag_merge
+// enabled, current tags on the object are retrieved with getCurrentTags and
+// tags applied outside of terraform are preserved.
+func getMergedTagsFromSchema(d *schema.ResourceData, getCurrentTags func() ([]common.Tag, error)) ([]common.Tag, error) {
+	tags := getTagsFromSchema(d)
+	if !d.Get("tag_merge").(bool) {
+		return tags, nil
+	}
+	currentTags, err := getCurrentTags()
+	if err != nil {
+		return nil, err
+	}
+	return mergeTags(currentTags, tags, getManagedTagScopes(d)), nil
+}
+
+// setMergedTagsInSchema sets tags in schema. With tag_merge enabled, only tags
+// with scopes owned by terraform are kept, so that tags applied outside of
+// terraform do not show as a diff.
+func setMergedTagsInSchema(d *schema.ResourceData, tags []common.Tag) {
+	if _, ok := d.GetOkExists("tag_merge"); !ok {
+		// Not kept on NSX side, populate the default on import
+		d.Set("tag_merge", false)
+	}
+	if d.Get("tag_merge").(bool) {
+		tags = filterManagedTags(tags, getTagScopesFromList(d.Get("tag").(*schema.Set).List()))
+	}
+	setTagsInSchema(d, tags)
+}
+
+// validateTagScopes checks that non-empty scopes are unique within the tag set.
+// Tags without scope are allowed to repeat.
+func validateTagScopes(tags []interface{}) error {
+	scopes := make(map[string]bool)
+	for _, tag := range tags {
+		data, ok := tag.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		scope, _ := data["scope"].(string)
+		if scope == "" {
+			continue
+		}
+		if scopes[scope] {
+			return fmt.Errorf("Duplicate tag scope '%s', each scope can only be specified once", scope)
+		}
+		scopes[scope] = true
+	}
+	return nil
+}
+
+func validateTagScopesDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	tags, ok := d.Get("tag").(*schema.Set)
+	if !ok || tags == nil {
+		return nil
+	}
+	return validateTagScopes(tags.List())
+}
+
+// addTagScopesValidation adds tag scope validation to manager resources
+// that define tags with getTagsSchema or getTagsSchemaForceNew, preceding
+// resource specific CustomizeDiff if any. Policy resources are not validated,
+// since policy objects commonly carry several tags with the same scope.
+func addTagScopesValidation(resources map[string]*schema.Resource) {
+	for name, resource := range resources {
+		if strings.HasPrefix(name, "nsxt_policy_") {
+			continue
+		}
+		tagSchema, ok := resource.Schema["tag"]
+		if !ok || tagSchema.Type != schema.TypeSet || tagSchema.Computed {
+			continue
+		}
+		customizeDiff := resource.CustomizeDiff
+		if customizeDiff == nil {
+			resource.CustomizeDiff = validateTagScopesDiff
+			continue
+		}
+		resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if err := validateTagScopesDiff(ctx, d, m); err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, m)
+		}
+	}
+}
+
 // utilities to define & handle switching profiles
+var switchingProfileTypeValues = []string{
+	"QosSwitchingProfile",
+	"PortMirroringSwitchingProfile",
+	"IpDiscoverySwitchingProfile",
+	"SpoofGuardSwitchingProfile",
+	"SwitchSecuritySwitchingProfile",
+	"MacManagementSwitchingProfile",
+}
+
 func getSwitchingProfileIdsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeSet,
@@ -167,9 +337,10 @@ func getSwitchingProfileIdsSchema() *schema.Schema {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"key": {
-					Type:        schema.TypeString,
-					Description: "The resource type of this profile",
-					Required:    true,
+					Type:         schema.TypeString,
+					Description:  "The resource type of this profile",
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(switchingProfileTypeValues, false),
 				},
 				"value": {
 					Type:        schema.TypeString,
@@ -285,8 +456,11 @@ func getResourceReferencesSetSchema(required bool, computed bool, validTargetTyp
 
 func getResourceReferencesSchemaByType(required bool, computed bool, validTargetTypes []string, isList bool, description string, maxItems int) *schema.Schema {
 	schType := schema.TypeSet
+	var setFunc schema.SchemaSetFunc
 	if isList {
 		schType = schema.TypeList
+	} else {
+		setFunc = resourceReferenceHash
 	}
 
 	return &schema.Schema{
@@ -296,6 +470,7 @@ func getResourceReferencesSchemaByType(required bool, computed bool, validTarget
 		Computed:    computed,
 		MaxItems:    maxItems,
 		Description: description,
+		Set:         setFunc,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"is_valid": {
@@ -317,13 +492,33 @@ func getResourceReferencesSchemaByType(required bool, computed bool, validTarget
 					Type:         schema.TypeString,
 					Description:  "Type of the NSX resource",
 					Optional:     true,
-					ValidateFunc: validation.StringInSlice(validTargetTypes, false),
+					ValidateFunc: validation.StringInSlice(validTargetTypes, true),
+					StateFunc:    getCanonicalTargetTypeFunc(validTargetTypes),
 				},
 			},
 		},
 	}
 }
 
+// getCanonicalTargetTypeFunc returns a function that converts target type to
+// the casing expected by NSX, as listed in validTargetTypes
+func getCanonicalTargetTypeFunc(validTargetTypes []string) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		return getCanonicalTargetType(v.(string), validTargetTypes)
+	}
+}
+
+// getCanonicalTargetType returns the entry of validTargetTypes that matches
+// targetType case-insensitively, or targetType itself if none matches
+func getCanonicalTargetType(targetType string, validTargetTypes []string) string {
+	for _, validType := range validTargetTypes {
+		if strings.EqualFold(targetType, validType) {
+			return validType
+		}
+	}
+	return targetType
+}
+
 func getSingleResourceReference(references []interface{}) *common.ResourceReference {
 	for _, reference := range references {
 		data := reference.(map[string]interface{})
@@ -332,7 +527,7 @@ func getSingleResourceReference(references []interface{}) *common.ResourceRefere
 			IsValid:           data["is_valid"].(bool),
 			TargetDisplayName: data["target_display_name"].(string),
 			TargetId:          data["target_id"].(string),
-			TargetType:        data["target_type"].(string),
+			TargetType:        getCanonicalTargetType(data["target_type"].(string), resourceReferenceTargetTypes),
 		}
 		return &elem
 	}
@@ -347,7 +542,7 @@ func getResourceReferences(references []interface{}) []common.ResourceReference
 			IsValid:           data["is_valid"].(bool),
 			TargetDisplayName: data["target_display_name"].(string),
 			TargetId:          data["target_id"].(string),
-			TargetType:        data["target_type"].(string),
+			TargetType:        getCanonicalTargetType(data["target_type"].(string), resourceReferenceTargetTypes),
 		}
 
 		referenceList = append(referenceList, elem)
@@ -378,7 +573,8 @@ func resourceReferenceHash(v interface{}) int {
 
 	if v != nil {
 		m := v.(map[string]interface{})
-		buf.WriteString(fmt.Sprintf("%s-%s", m["target_type"], m["target_id"]))
+		// Target type is case insensitive, see getCanonicalTargetType
+		buf.WriteString(fmt.Sprintf("%s-%s", strings.ToLower(fmt.Sprintf("%s", m["target_type"])), m["target_id"]))
 	}
 	result := int(crc32.ChecksumIEEE(buf.Bytes()))
 	if result < 0 {
@@ -433,7 +629,7 @@ func getServiceBindingsFromSchema(d *schema.ResourceData, schemaAttrName string)
 			IsValid:           data["is_valid"].(bool),
 			TargetDisplayName: data["target_display_name"].(string),
 			TargetId:          data["target_id"].(string),
-			TargetType:        data["target_type"].(string),
+			TargetType:        getCanonicalTargetType(data["target_type"].(string), resourceReferenceTargetTypes),
 		}
 		elem := manager.ServiceBinding{ServiceId: &ref}
 		bindingList = append(bindingList, elem)
@@ -633,6 +829,10 @@ type paginationInfo struct {
 	LocalVarOptionals map[string]interface{}
 }
 
+// handlePagination calls lister until all pages are fetched, passing the
+// cursor from previous page. Listing stops when the total count was inspected,
+// or when NSX returns an empty page or no cursor, which may happen if objects
+// are deleted while listing.
 func handlePagination(lister func(*paginationInfo) error) (int64, error) {
 	info := paginationInfo{}
 	info.LocalVarOptionals = make(map[string]interface{})
@@ -655,6 +855,10 @@ func handlePagination(lister func(*paginationInfo) error) (int64, error) {
 			}
 		}
 		count += info.PageCount
+		if count < total && (info.PageCount == 0 || info.Cursor == "") {
+			log.Printf("[DEBUG] Pagination ended after %d/%d inspected", count, total)
+			break
+		}
 		log.Printf("[DEBUG] Fetching next page after %d/%d inspected", count, total)
 
 		info.LocalVarOptionals["cursor"] = info.Cursor
@@ -662,3 +866,61 @@ func handlePagination(lister func(*paginationInfo) error) (int64, error) {
 
 	return total, nil
 }
+
+// managerContext carries the cancellation and deadline of a terraform
+// operation, while resolving values (such as manager credentials) from the
+// NSX manager client context
+type managerContext struct {
+	context.Context
+	clientContext context.Context
+}
+
+func (c managerContext) Value(key interface{}) interface{} {
+	if value := c.Context.Value(key); value != nil {
+		return value
+	}
+	return c.clientContext.Value(key)
+}
+
+// getManagerContext returns a context suitable for NSX manager API calls, that
+// is cancelled together with the given terraform operation context
+func getManagerContext(ctx context.Context, nsxClient *api.APIClient) context.Context {
+	return managerContext{Context: ctx, clientContext: nsxClient.Context}
+}
+
+// getOperationContext returns a context suitable for NSX manager API calls,
+// derived from the given terraform operation context and bounded by the
+// timeout configured for the given operation of the resource
+func getOperationContext(ctx context.Context, nsxClient *api.APIClient, d *schema.ResourceData, operation string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(getManagerContext(ctx, nsxClient), d.Timeout(operation))
+}
+
+// withManagerDiagnostics adapts context-aware CRUD functions of manager
+// resources to the diagnostics based signature expected by the SDK
+func withManagerDiagnostics(f func(context.Context, *schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return diag.FromErr(f(ctx, d, m))
+	}
+}
+
+// Maximal number of times an update is retried after a revision conflict
+const conflictRetryMaxAttempts = 3
+
+// retryUponConflict calls update, and if NSX rejects it with 412 Precondition
+// Failed due to a stale revision and retry is enabled, calls refresh to set the
+// latest revision in the update payload and retries the update
+func retryUponConflict(enabled bool, update func() (*http.Response, error), refresh func() error) (*http.Response, error) {
+	resp, err := update()
+	for attempt := 1; enabled && attempt <= conflictRetryMaxAttempts; attempt++ {
+		if resp == nil || resp.StatusCode != http.StatusPreconditionFailed {
+			break
+		}
+		log.Printf("[DEBUG] Revision conflict during update, refreshing revision and retrying (attempt %d/%d)", attempt, conflictRetryMaxAttempts)
+		if refreshErr := refresh(); refreshErr != nil {
+			return resp, fmt.Errorf("%v (failed to refresh revision: %v)", err, refreshErr)
+		}
+		resp, err = update()
+	}
+
+	return resp, err
+}
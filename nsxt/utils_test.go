@@ -4,16 +4,22 @@
 package nsxt
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	api "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/common"
 	"github.com/vmware/go-vmware-nsxt/trust"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/protocol/client"
@@ -492,3 +498,326 @@ func testAccNsxtPolicyResourceCheckDestroy(state *terraform.State, displayName s
 	}
 	return nil
 }
+
+// testAccNSXResourcePresence interprets the response of a manager API read,
+// considering the object absent when NSX responds with one of notFoundCodes
+func testAccNSXResourcePresence(resp *http.Response, err error, notFoundCodes ...int) (bool, error) {
+	if resp != nil {
+		for _, code := range append(notFoundCodes, http.StatusNotFound) {
+			if resp.StatusCode == code {
+				return false, nil
+			}
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("Unexpected response from NSX manager: %v", resp)
+	}
+	return true, nil
+}
+
+// testAccNSXResourcePresenceChecker reads the object of a manager resource
+// from NSX, and returns its display name and whether it exists
+type testAccNSXResourcePresenceChecker func(*api.APIClient, *terraform.ResourceState) (string, bool, error)
+
+// testAccNSXResourceExists verifies that the object of a manager resource
+// exists on NSX with the given display name
+func testAccNSXResourceExists(resourceName string, displayName string, presenceChecker testAccNSXResourcePresenceChecker) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+
+		nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
+
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("NSX resource %s not found in resources", resourceName)
+		}
+
+		resourceID := rs.Primary.ID
+		if resourceID == "" {
+			return fmt.Errorf("NSX resource ID not set in resources")
+		}
+
+		nsxDisplayName, exists, err := presenceChecker(nsxClient, rs)
+		if err != nil {
+			return fmt.Errorf("Error while retrieving NSX resource %s: %v", resourceID, err)
+		}
+
+		if !exists {
+			return fmt.Errorf("NSX resource %s does not exist", resourceID)
+		}
+
+		if nsxDisplayName != displayName {
+			return fmt.Errorf("NSX resource %s has display name '%s', expected '%s'", resourceID, nsxDisplayName, displayName)
+		}
+
+		return nil
+	}
+}
+
+// newTestManagerClient returns a manager API client that sends requests to a
+// local test server with the given handler, and a function to stop the server
+func newTestManagerClient(t *testing.T, handler http.HandlerFunc) (*api.APIClient, func()) {
+	server := httptest.NewServer(handler)
+	nsxClient, err := api.NewAPIClient(&api.Configuration{
+		BasePath:        "/api/v1",
+		Host:            strings.TrimPrefix(server.URL, "http://"),
+		Scheme:          "http",
+		SkipSessionAuth: true,
+	})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return nsxClient, server.Close
+}
+
+func testAccNSXResourceCheckDestroy(state *terraform.State, displayName string, resourceType string, presenceChecker testAccNSXResourcePresenceChecker) error {
+	nsxClient := testAccProvider.Meta().(nsxtClients).NsxtClient
+	for _, rs := range state.RootModule().Resources {
+
+		if rs.Type != resourceType {
+			continue
+		}
+
+		_, exists, err := presenceChecker(nsxClient, rs)
+		if err != nil {
+			return fmt.Errorf("Error while retrieving NSX resource %s: %v", rs.Primary.ID, err)
+		}
+		if exists {
+			return fmt.Errorf("NSX resource %s still exists", displayName)
+		}
+	}
+	return nil
+}
+
+func TestRetryUponConflict(t *testing.T) {
+	// Simulate an object modified concurrently, so that the payload revision is stale
+	serverRevision := int64(5)
+	updateCalls := 0
+	var payloadRevision int64
+	update := func() (*http.Response, error) {
+		updateCalls++
+		if payloadRevision != serverRevision {
+			return &http.Response{StatusCode: http.StatusPreconditionFailed}, fmt.Errorf("412 Precondition Failed")
+		}
+		serverRevision++
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+	refresh := func() error {
+		payloadRevision = serverRevision
+		return nil
+	}
+
+	// Retry disabled: the conflict is returned as is
+	payloadRevision = 3
+	resp, err := retryUponConflict(false, update, refresh)
+	if err == nil || resp.StatusCode != http.StatusPreconditionFailed || updateCalls != 1 {
+		t.Errorf("Expected conflict to be returned without retry, got status %d after %d calls", resp.StatusCode, updateCalls)
+	}
+
+	// Retry enabled: the revision is refreshed and the update succeeds
+	updateCalls = 0
+	payloadRevision = 3
+	resp, err = retryUponConflict(true, update, refresh)
+	if err != nil || resp.StatusCode != http.StatusOK || updateCalls != 2 {
+		t.Errorf("Expected update to succeed after refresh, got error %v after %d calls", err, updateCalls)
+	}
+	if serverRevision != 6 {
+		t.Errorf("Expected server revision to be bumped to 6, got %d", serverRevision)
+	}
+
+	// Persistent conflict: retries stop after the maximal number of attempts
+	updateCalls = 0
+	resp, err = retryUponConflict(true, update, func() error { return nil })
+	if err == nil || resp.StatusCode != http.StatusPreconditionFailed || updateCalls != conflictRetryMaxAttempts+1 {
+		t.Errorf("Expected %d update calls before giving up, got %d", conflictRetryMaxAttempts+1, updateCalls)
+	}
+}
+
+func TestGetOperationContext(t *testing.T) {
+	auth := api.BasicAuth{UserName: "admin", Password: "secret"}
+	nsxClient := &api.APIClient{Context: context.WithValue(context.Background(), api.ContextBasicAuth, auth)}
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := getOperationContext(parent, nsxClient, d, schema.TimeoutCreate)
+	defer cancel()
+
+	// Manager credentials are resolved from the client context
+	if value, ok := ctx.Value(api.ContextBasicAuth).(api.BasicAuth); !ok || value != auth {
+		t.Errorf("Expected manager credentials to be carried by the operation context, got %v", ctx.Value(api.ContextBasicAuth))
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		t.Errorf("Expected operation context to be bounded by the resource timeout")
+	}
+
+	// Cancelling the terraform operation cancels the manager calls
+	cancelParent()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Errorf("Expected operation context to be cancelled together with the terraform operation")
+	}
+}
+
+func TestHandlePagination(t *testing.T) {
+	// Fake paging over 7 objects with page size 3
+	objects := []string{"a", "b", "c", "d", "e", "f", "g"}
+	pageSize := 3
+	var fetched []string
+	var cursors []string
+	lister := func(info *paginationInfo) error {
+		cursor, _ := info.LocalVarOptionals["cursor"].(string)
+		cursors = append(cursors, cursor)
+		start := 0
+		if cursor != "" {
+			fmt.Sscanf(cursor, "%d", &start)
+		}
+		end := start + pageSize
+		if end > len(objects) {
+			end = len(objects)
+		}
+		fetched = append(fetched, objects[start:end]...)
+
+		info.PageCount = int64(end - start)
+		info.TotalCount = int64(len(objects))
+		info.Cursor = ""
+		if end < len(objects) {
+			info.Cursor = fmt.Sprintf("%d", end)
+		}
+		return nil
+	}
+
+	total, err := handlePagination(lister)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total != int64(len(objects)) {
+		t.Errorf("Expected total %d, got %d", len(objects), total)
+	}
+	if strings.Join(fetched, "") != strings.Join(objects, "") {
+		t.Errorf("Expected all objects to be fetched, got %v", fetched)
+	}
+	if strings.Join(cursors, ",") != ",3,6" {
+		t.Errorf("Unexpected cursors %v", cursors)
+	}
+
+	// Objects deleted while listing: the last page is empty and has no cursor
+	calls := 0
+	shrinkingLister := func(info *paginationInfo) error {
+		calls++
+		if calls > 2 {
+			t.Fatalf("Expected pagination to stop on empty page")
+		}
+		info.TotalCount = 5
+		info.PageCount = 3
+		info.Cursor = "3"
+		if calls == 2 {
+			info.PageCount = 0
+			info.Cursor = ""
+		}
+		return nil
+	}
+	if _, err := handlePagination(shrinkingLister); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Errors are propagated
+	failingLister := func(info *paginationInfo) error {
+		return fmt.Errorf("failed")
+	}
+	if _, err := handlePagination(failingLister); err == nil {
+		t.Errorf("Expected error to be propagated")
+	}
+}
+
+func TestValidateTagScopes(t *testing.T) {
+	tag := func(scope string, value string) interface{} {
+		return map[string]interface{}{"scope": scope, "tag": value}
+	}
+
+	if err := validateTagScopes([]interface{}{tag("scope1", "tag1"), tag("scope2", "tag1")}); err != nil {
+		t.Errorf("Unexpected error for unique scopes: %v", err)
+	}
+	if err := validateTagScopes([]interface{}{tag("", "tag1"), tag("", "tag2")}); err != nil {
+		t.Errorf("Unexpected error for empty scopes: %v", err)
+	}
+	err := validateTagScopes([]interface{}{tag("scope1", "tag1"), tag("scope1", "tag2")})
+	if err == nil || !strings.Contains(err.Error(), "scope1") {
+		t.Errorf("Expected duplicate scope error, got %v", err)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	existingTags := []common.Tag{
+		{Scope: "team", Tag: "old"},
+		{Scope: "owner", Tag: "ops"},
+		{Scope: "removed", Tag: "value"},
+	}
+	configuredTags := []common.Tag{{Scope: "team", Tag: "new"}}
+	managedScopes := map[string]bool{"team": true, "removed": true}
+
+	tags := mergeTags(existingTags, configuredTags, managedScopes)
+	expected := []common.Tag{{Scope: "owner", Tag: "ops"}, {Scope: "team", Tag: "new"}}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected merged tags %v, got %v", expected, tags)
+	}
+
+	filtered := filterManagedTags(existingTags, map[string]bool{"team": true})
+	expected = []common.Tag{{Scope: "team", Tag: "old"}}
+	if !reflect.DeepEqual(filtered, expected) {
+		t.Errorf("Expected filtered tags %v, got %v", expected, filtered)
+	}
+}
+
+func TestGetResourceReferencesTargetTypeCasing(t *testing.T) {
+	refSchema := getResourceReferencesSetSchema(false, false, []string{"IPSet", "NSGroup"}, "test")
+	validate := refSchema.Elem.(*schema.Resource).Schema["target_type"].ValidateFunc
+	if _, errs := validate("nsgroup", "target_type"); len(errs) > 0 {
+		t.Errorf("Expected nsgroup to be accepted, got %v", errs)
+	}
+	if _, errs := validate("LogicalPort", "target_type"); len(errs) == 0 {
+		t.Errorf("Expected LogicalPort to be rejected")
+	}
+
+	references := getResourceReferences([]interface{}{
+		map[string]interface{}{"is_valid": false, "target_display_name": "", "target_id": "g1", "target_type": "nsgroup"},
+		map[string]interface{}{"is_valid": false, "target_display_name": "", "target_id": "s1", "target_type": "IPSET"},
+	})
+	for i, expected := range []string{"NSGroup", "IPSet"} {
+		if references[i].TargetType != expected {
+			t.Errorf("Expected target type %s, got %s", expected, references[i].TargetType)
+		}
+	}
+
+	lower := map[string]interface{}{"target_type": "nsgroup", "target_id": "g1"}
+	canonical := map[string]interface{}{"target_type": "NSGroup", "target_id": "g1"}
+	if resourceReferenceHash(lower) != resourceReferenceHash(canonical) {
+		t.Errorf("Expected hash to ignore target type casing")
+	}
+}
+
+func TestAddTagScopesValidation(t *testing.T) {
+	resources := map[string]*schema.Resource{
+		"nsxt_ip_set":       {Schema: map[string]*schema.Schema{"tag": getTagsSchema()}},
+		"nsxt_policy_group": {Schema: map[string]*schema.Schema{"tag": getTagsSchema()}},
+	}
+	addTagScopesValidation(resources)
+
+	if resources["nsxt_policy_group"].CustomizeDiff != nil {
+		t.Errorf("Expected policy resource not to validate tag scopes")
+	}
+	managerResource := resources["nsxt_ip_set"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"tag": []interface{}{
+			map[string]interface{}{"scope": "color", "tag": "red"},
+			map[string]interface{}{"scope": "color", "tag": "orange"},
+		},
+	})
+	_, err := schema.InternalMap(managerResource.Schema).Diff(context.Background(), nil, config, managerResource.CustomizeDiff, nil, true)
+	if err == nil || !strings.Contains(err.Error(), "color") {
+		t.Errorf("Expected duplicate scope error for manager resource, got %v", err)
+	}
+}
@@ -20,30 +20,56 @@ func isSinglePort(v string) bool {
 	if err != nil {
 		return false
 	}
-	if i > 65536 {
+	if i > 65535 {
 		return false
 	}
 	return true
 }
 
 func isPortRange(v string) bool {
+	return checkPortRange(v) == nil
+}
+
+// checkPortRange returns a descriptive error if v is not a valid
+// "low-high" port range
+func checkPortRange(v string) error {
 	s := strings.Split(v, "-")
 	if len(s) != 2 {
-		return false
+		return fmt.Errorf("%q is not a port range", v)
 	}
-	if !isSinglePort(s[0]) || !isSinglePort(s[1]) {
-		return false
+	for _, port := range s {
+		if !isSinglePort(port) {
+			return fmt.Errorf("%q in range %q is not a valid port number (0-65535)", port, v)
+		}
 	}
-	return true
+	low, _ := strconv.ParseUint(s[0], 10, 32)
+	high, _ := strconv.ParseUint(s[1], 10, 32)
+	if low > high {
+		return fmt.Errorf("lower port %d is greater than upper port %d in range %q", low, high, v)
+	}
+	return nil
 }
 
 func validatePortRange() schema.SchemaValidateFunc {
 	// A single port num or a range of ports
 	return func(v interface{}, k string) (ws []string, errors []error) {
 		value := v.(string)
-		if !isPortRange(value) && !isSinglePort(value) {
+		if value == "" {
+			errors = append(errors, fmt.Errorf(
+				"expected %q to be a port range or a single port, got empty string", k))
+			return
+		}
+		if isSinglePort(value) {
+			return
+		}
+		if !strings.Contains(value, "-") {
+			errors = append(errors, fmt.Errorf(
+				"expected %q to be a port range or a single port (0-65535). Got %s", k, value))
+			return
+		}
+		if err := checkPortRange(value); err != nil {
 			errors = append(errors, fmt.Errorf(
-				"expected %q to be a port range or a single port. Got %s", k, value))
+				"expected %q to be a port range or a single port: %v", k, err))
 		}
 		return
 	}
@@ -135,6 +161,22 @@ func validateCidrOrIPOrRange() schema.SchemaValidateFunc {
 	}
 }
 
+func validateCidrOrIP() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+
+		if !isCidr(v, true, false) && !isSingleIP(v) {
+			es = append(es, fmt.Errorf(
+				"expected %s to contain a valid IPv4 or IPv6 address or CIDR, got: %s", k, v))
+		}
+		return
+	}
+}
+
 func validateIPOrRange() schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (s []string, es []error) {
 		v, ok := i.(string)
@@ -167,6 +209,52 @@ func validateSingleIP() schema.SchemaValidateFunc {
 	}
 }
 
+// Validations for MAC objects
+
+// isMacAddress accepts MAC-48 addresses in colon or hyphen notation
+func isMacAddress(v string) bool {
+	if len(v) != 17 {
+		return false
+	}
+	mac, err := net.ParseMAC(v)
+	return err == nil && len(mac) == 6
+}
+
+func validateMacAddress() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+
+		if !isMacAddress(v) {
+			es = append(es, fmt.Errorf(
+				"expected %s to contain a valid MAC address, got: %s", k, v))
+		}
+		return
+	}
+}
+
+// Maximal length of rule tag printed in CLI and packet logs, enforced by NSX
+const firewallRuleTagMaxLength = 32
+
+func validateFirewallRuleTag() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+
+		if len(v) > firewallRuleTagMaxLength {
+			es = append(es, fmt.Errorf(
+				"expected length of %s to be at most %d, got %d", k, firewallRuleTagMaxLength, len(v)))
+		}
+		return
+	}
+}
+
 func isValidStringUint(value string, bits int) bool {
 	_, err := strconv.ParseUint(value, 10, bits)
 	return (err == nil)
@@ -0,0 +1,101 @@
+/* Copyright © 2020 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePortRange(t *testing.T) {
+	validValues := []string{"0", "80", "65535", "1000-2000", "443-443", "0-65535"}
+	invalidValues := []string{"", "abc", "80-", "-80", "65536", "1-65536", "2000-1000", "1-2-3", "80,81", " 80"}
+
+	validator := validatePortRange()
+	for _, value := range validValues {
+		if _, errs := validator(value, "destination_ports"); len(errs) > 0 {
+			t.Errorf("Expected %q to be valid, got %v", value, errs)
+		}
+	}
+
+	for _, value := range invalidValues {
+		if _, errs := validator(value, "destination_ports"); len(errs) == 0 {
+			t.Errorf("Expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestValidateSinglePort(t *testing.T) {
+	validator := validateSinglePort()
+	for _, value := range []string{"0", "22", "65535"} {
+		if _, errs := validator(value, "port"); len(errs) > 0 {
+			t.Errorf("Expected %q to be valid, got %v", value, errs)
+		}
+	}
+	for _, value := range []string{"", "ssh", "65536", "22-23"} {
+		if _, errs := validator(value, "port"); len(errs) == 0 {
+			t.Errorf("Expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestValidateCidrOrIPOrRange(t *testing.T) {
+	validValues := []string{"4.4.4.4", "4.4.4.1-4.4.4.10", "4.4.4.0/24", "2001:db8::1", "2001:db8::1-2001:db8::10", "2001:db8::/64"}
+	invalidValues := []string{"", "4.4.4", "4.4.4.1-", "4.4.4.1-4.4.4.10-4.4.4.20", "4.4.4.0/33", "4.4.4.1/24", "4.4.4.1 - 4.4.4.10", "host.example.com"}
+
+	validator := validateCidrOrIPOrRange()
+	for _, value := range validValues {
+		if _, errs := validator(value, "translated_network"); len(errs) > 0 {
+			t.Errorf("Expected %q to be valid, got %v", value, errs)
+		}
+	}
+	for _, value := range invalidValues {
+		if _, errs := validator(value, "translated_network"); len(errs) == 0 {
+			t.Errorf("Expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestValidateCidrOrIP(t *testing.T) {
+	validValues := []string{"4.4.4.4", "4.4.4.0/24", "0.0.0.0/0", "2001:db8::1", "2001:db8::/64", "::/0"}
+	invalidValues := []string{"", "4.4.4", "4.4.4.1-4.4.4.10", "4.4.4.1/24", "2001:db8::1/64", "2001:db8::/129", "host.example.com"}
+
+	validator := validateCidrOrIP()
+	for _, value := range validValues {
+		if _, errs := validator(value, "match_source_network"); len(errs) > 0 {
+			t.Errorf("Expected %q to be valid, got %v", value, errs)
+		}
+	}
+	for _, value := range invalidValues {
+		if _, errs := validator(value, "match_source_network"); len(errs) == 0 {
+			t.Errorf("Expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestValidateFirewallRuleTag(t *testing.T) {
+	validator := validateFirewallRuleTag()
+	for _, value := range []string{"", "web-allow", "test rule tag", strings.Repeat("a", 32)} {
+		if _, errs := validator(value, "rule_tag"); len(errs) > 0 {
+			t.Errorf("Expected %q to be valid, got %v", value, errs)
+		}
+	}
+	if _, errs := validator(strings.Repeat("a", 33), "rule_tag"); len(errs) == 0 {
+		t.Errorf("Expected rule tag longer than %d characters to be invalid", firewallRuleTagMaxLength)
+	}
+}
+
+func TestValidateMacAddress(t *testing.T) {
+	validator := validateMacAddress()
+	for _, value := range []string{"00:50:56:ab:cd:ef", "00:50:56:AB:CD:EF", "00-50-56-ab-cd-ef"} {
+		if _, errs := validator(value, "mac_addresses"); len(errs) > 0 {
+			t.Errorf("Expected %q to be valid, got %v", value, errs)
+		}
+	}
+	for _, value := range []string{"", "00:50:56:ab:cd", "00:50:56:ab:cd:ef:01:02", "0050.56ab.cdef", "00:50:56:ab:cd:eg"} {
+		if _, errs := validator(value, "mac_addresses"); len(errs) == 0 {
+			t.Errorf("Expected %q to be invalid", value)
+		}
+	}
+}